@@ -0,0 +1,11 @@
+# The provider logs in with MASTODON_USER_EMAIL and MASTODON_USER_PASSWORD.
+provider "mastodon" {
+  host = "https://mastodon.social"
+}
+
+data "mastodon_access_token" "example" {}
+
+output "access_token" {
+  value     = data.mastodon_access_token.example.access_token
+  sensitive = true
+}
@@ -0,0 +1,9 @@
+data "mastodon_follow_requests" "pending" {}
+
+output "pending_requests" {
+  value = data.mastodon_follow_requests.pending.pending_count
+}
+
+output "pending_handles" {
+  value = [for account in data.mastodon_follow_requests.pending.accounts : account.acct]
+}
@@ -0,0 +1 @@
+data "mastodon_instance" "example" {}
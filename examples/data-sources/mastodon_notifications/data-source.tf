@@ -0,0 +1,10 @@
+data "mastodon_notifications" "mentions" {
+  types = ["mention"]
+  limit = 20
+}
+
+resource "mastodon_favourite" "mentions" {
+  for_each = toset([for notification in data.mastodon_notifications.mentions.notifications : notification.status_id])
+
+  status_id = each.value
+}
@@ -0,0 +1,5 @@
+data "mastodon_rate_limit" "example" {}
+
+output "can_bulk_post" {
+  value = data.mastodon_rate_limit.example.remaining > 100
+}
@@ -0,0 +1,7 @@
+data "mastodon_account" "example" {
+  username = "@tedivm@hachyderm.io"
+}
+
+data "mastodon_relationship" "example" {
+  account_id = data.mastodon_account.example.id
+}
@@ -0,0 +1,4 @@
+data "mastodon_resolved_identity" "example" {
+  username = "tedivm"
+  server   = "hachyderm.io"
+}
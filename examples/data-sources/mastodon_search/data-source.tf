@@ -0,0 +1,8 @@
+data "mastodon_search" "example" {
+  query = "Robert Hafner"
+  type  = "accounts"
+}
+
+resource "mastodon_follow" "example" {
+  account_id = data.mastodon_search.example.account_ids[0]
+}
@@ -0,0 +1,3 @@
+data "mastodon_status" "example" {
+  url = "https://hachyderm.io/@tedivm/109372843234"
+}
@@ -0,0 +1,9 @@
+data "mastodon_timeline" "example" {
+  type  = "tag"
+  tag   = "caturday"
+  limit = 100
+}
+
+output "caturday_posters" {
+  value = distinct([for status in data.mastodon_timeline.example.statuses : status.account_id])
+}
@@ -0,0 +1,10 @@
+data "mastodon_trends" "example" {
+  limit = 5
+}
+
+output "busy_tags" {
+  value = [
+    for tag in data.mastodon_trends.example.tags : tag.name
+    if max(values(tag.daily_uses)...) > 100
+  ]
+}
@@ -0,0 +1,15 @@
+resource "mastodon_application" "bot" {
+  client_name = "Release Announcer"
+  scopes      = ["read", "write:statuses"]
+  website     = "https://example.com/announcer"
+}
+
+# The credentials can configure the provider in another workspace.
+output "client_id" {
+  value = mastodon_application.bot.client_id
+}
+
+output "client_secret" {
+  value     = mastodon_application.bot.client_secret
+  sensitive = true
+}
@@ -0,0 +1,2 @@
+# Blocks are imported by the ID of the blocked account.
+terraform import mastodon_block.example 109323411354066371
@@ -0,0 +1,16 @@
+variable "blocked_accounts" {
+  type    = set(string)
+  default = ["spammer@spam.example"]
+}
+
+data "mastodon_account" "blocked" {
+  for_each = var.blocked_accounts
+
+  username = each.value
+}
+
+resource "mastodon_block" "blocked" {
+  for_each = data.mastodon_account.blocked
+
+  account_id = each.value.id
+}
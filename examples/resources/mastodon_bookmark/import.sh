@@ -0,0 +1,2 @@
+# Bookmarks are imported by the ID of the bookmarked status.
+terraform import mastodon_bookmark.example 112345678901234567
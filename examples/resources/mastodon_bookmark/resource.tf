@@ -0,0 +1,3 @@
+resource "mastodon_bookmark" "example" {
+  status_id = mastodon_post.example.id
+}
@@ -0,0 +1,3 @@
+# Boosts are imported by the ID of the boosted status. The reblog_id of an
+# imported boost is not known.
+terraform import mastodon_boost.example 112345678901234567
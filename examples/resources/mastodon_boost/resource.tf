@@ -0,0 +1,4 @@
+resource "mastodon_boost" "example" {
+  status_id  = mastodon_post.example.id
+  visibility = "unlisted"
+}
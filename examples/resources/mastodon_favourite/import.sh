@@ -0,0 +1,2 @@
+# Favourites are imported by the ID of the favourited status.
+terraform import mastodon_favourite.example 112345678901234567
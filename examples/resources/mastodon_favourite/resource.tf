@@ -0,0 +1,3 @@
+resource "mastodon_favourite" "example" {
+  status_id = mastodon_post.example.id
+}
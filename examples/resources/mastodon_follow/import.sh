@@ -0,0 +1,2 @@
+# Follows are imported by the ID of the followed account.
+terraform import mastodon_follow.example 109323411354066371
@@ -0,0 +1,4 @@
+resource "mastodon_follow" "tedivm" {
+  acct    = "tedivm@hachyderm.io"
+  reblogs = false
+}
@@ -0,0 +1,3 @@
+# List members are imported by the ID of the list and the ID of the account,
+# separated by a colon.
+terraform import mastodon_list_member.example 12345:109323411354066371
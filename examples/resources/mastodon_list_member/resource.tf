@@ -0,0 +1,8 @@
+data "mastodon_account" "tedivm" {
+  username = "tedivm@hachyderm.io"
+}
+
+resource "mastodon_list_member" "tedivm" {
+  list_id    = "12345"
+  account_id = data.mastodon_account.tedivm.id
+}
@@ -0,0 +1,3 @@
+# List memberships are imported by the ID of the list. Every current member of
+# the list is taken over.
+terraform import mastodon_list_membership.example 12345
@@ -0,0 +1,9 @@
+data "mastodon_account" "friends" {
+  for_each = toset(["tedivm@hachyderm.io", "mastodon@mastodon.social"])
+  username = each.key
+}
+
+resource "mastodon_list_membership" "friends" {
+  list_id     = "12345"
+  account_ids = [for account in data.mastodon_account.friends : account.id]
+}
@@ -0,0 +1,10 @@
+resource "mastodon_media" "logo" {
+  file        = "${path.module}/logo.png"
+  description = "The project logo: a woolly mammoth wearing a hard hat."
+  focus       = "0.0,0.2"
+}
+
+resource "mastodon_post" "announcement" {
+  content   = "We have a new logo!"
+  media_ids = [mastodon_media.logo.id]
+}
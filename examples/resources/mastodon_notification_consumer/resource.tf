@@ -0,0 +1,13 @@
+resource "mastodon_notification_consumer" "example" {
+  max_events      = 50
+  timeout_seconds = 60
+
+  # Consume again whenever the bot's code changes.
+  triggers = {
+    bot_version = "1.4.0"
+  }
+}
+
+output "notifications_seen" {
+  value = mastodon_notification_consumer.example.events_seen
+}
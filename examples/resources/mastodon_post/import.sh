@@ -0,0 +1,4 @@
+# Posts can be imported by their ID or public URL. Only posts owned by the
+# authenticated account can be imported.
+terraform import mastodon_post.example 112233445566778899
+terraform import mastodon_post.example https://mastodon.social/@example/112233445566778899
@@ -0,0 +1,4 @@
+resource "mastodon_status_reaction" "example" {
+  status_id = mastodon_post.example.id
+  emoji     = "🎉"
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AccessTokenDataSource{}
+
+func NewAccessTokenDataSource() datasource.DataSource {
+	return &AccessTokenDataSource{}
+}
+
+// AccessTokenDataSource defines the data source implementation.
+type AccessTokenDataSource struct {
+	client *MastodonClient
+}
+
+// AccessTokenDataSourceModel describes the data source data model.
+type AccessTokenDataSourceModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	AccountId   types.String `tfsdk:"account_id"`
+	Acct        types.String `tfsdk:"acct"`
+}
+
+func (d *AccessTokenDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (d *AccessTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source exposes the access token the provider authenticates with. " +
+			"When the provider is configured with `email` and `password`, this is the token it obtained for them when it was configured, so it can be handed to other tooling without logging in again. " +
+			"When it is configured with `access_token`, that token is returned as is.",
+
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "The access token of the authenticated account.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the account the token belongs to.",
+				Computed:            true,
+			},
+			"acct": schema.StringAttribute{
+				MarkdownDescription: "Handle of the account the token belongs to.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AccessTokenDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AccessTokenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccessTokenDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_access_token data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.AccessToken = types.StringValue(d.client.Config.AccessToken)
+	data.AccountId = types.StringValue(string(d.client.currentUser.ID))
+	data.Acct = types.StringValue(d.client.currentUser.Acct)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_access_token data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
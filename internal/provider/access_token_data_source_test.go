@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccAccessTokenDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAccessTokenDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_access_token.test", "access_token"),
+					resource.TestCheckResourceAttrSet("data.mastodon_access_token.test", "account_id"),
+					resource.TestCheckResourceAttrSet("data.mastodon_access_token.test", "acct"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAccessTokenDataSourceConfig = `
+data "mastodon_access_token" "test" {}
+`
+
+func TestAccessTokenDataSource_Read(t *testing.T) {
+	d := &AccessTokenDataSource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: "https://mastodon.example", AccessToken: "derived-token"}),
+		currentUser: &mastodon.Account{ID: "1", Acct: "tedivm"},
+	}}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, nil),
+		"account_id":   tftypes.NewValue(tftypes.String, nil),
+		"acct":         tftypes.NewValue(tftypes.String, nil),
+	})}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data AccessTokenDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	assert.Equal(t, types.StringValue("derived-token"), data.AccessToken)
+	assert.Equal(t, types.StringValue("1"), data.AccountId)
+	assert.Equal(t, types.StringValue("tedivm"), data.Acct)
+}
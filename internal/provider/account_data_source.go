@@ -3,16 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mattn/go-mastodon"
+	"github.com/microcosm-cc/bluemonday"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &AccountDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &AccountDataSource{}
 
 func NewAccountDataSource() datasource.DataSource {
 	return &AccountDataSource{}
@@ -20,17 +28,75 @@ func NewAccountDataSource() datasource.DataSource {
 
 // AccountDataSource defines the data source implementation.
 type AccountDataSource struct {
-	client *mastodon.Client
+	client *MastodonClient
 }
 
 // AccountDataSourceModel describes the data source data model.
 type AccountDataSourceModel struct {
-	Username    types.String `tfsdk:"username"`
-	Id          types.String `tfsdk:"id"`
-	DisplayName types.String `tfsdk:"display_name"`
-	Note        types.String `tfsdk:"note"`
-	Locked      types.Bool   `tfsdk:"locked"`
-	Bot         types.Bool   `tfsdk:"bot"`
+	Username     types.String        `tfsdk:"username"`
+	Url          types.String        `tfsdk:"url"`
+	Id           types.String        `tfsdk:"id"`
+	Acct         types.String        `tfsdk:"acct"`
+	DisplayName  types.String        `tfsdk:"display_name"`
+	Note         types.String        `tfsdk:"note"`
+	Locked       types.Bool          `tfsdk:"locked"`
+	Bot          types.Bool          `tfsdk:"bot"`
+	Avatar       types.String        `tfsdk:"avatar"`
+	AvatarStatic types.String        `tfsdk:"avatar_static"`
+	Header       types.String        `tfsdk:"header"`
+	HeaderStatic types.String        `tfsdk:"header_static"`
+	CreatedAt    types.String        `tfsdk:"created_at"`
+	LastStatusAt types.String        `tfsdk:"last_status_at"`
+	Fields       []AccountFieldModel `tfsdk:"fields"`
+	Emojis       []AccountEmojiModel `tfsdk:"emojis"`
+	Suspended    types.Bool          `tfsdk:"suspended"`
+	Limited      types.Bool          `tfsdk:"limited"`
+
+	FollowersCount types.Int64 `tfsdk:"followers_count"`
+	FollowingCount types.Int64 `tfsdk:"following_count"`
+	StatusesCount  types.Int64 `tfsdk:"statuses_count"`
+
+	Resolved         types.Bool   `tfsdk:"resolved"`
+	ResolutionSource types.String `tfsdk:"resolution_source"`
+
+	IncludeRelationship types.Bool `tfsdk:"include_relationship"`
+	Following           types.Bool `tfsdk:"following"`
+	FollowedBy          types.Bool `tfsdk:"followed_by"`
+	Requested           types.Bool `tfsdk:"requested"`
+}
+
+// AccountFieldModel describes a profile metadata field.
+type AccountFieldModel struct {
+	Name       types.String `tfsdk:"name"`
+	Value      types.String `tfsdk:"value"`
+	VerifiedAt types.String `tfsdk:"verified_at"`
+}
+
+// AccountEmojiModel describes a custom emoji used in the account's display
+// name or note.
+type AccountEmojiModel struct {
+	Shortcode types.String `tfsdk:"shortcode"`
+	Url       types.String `tfsdk:"url"`
+	StaticUrl types.String `tfsdk:"static_url"`
+}
+
+// Ways an account can be resolved, reported in `resolution_source`.
+const (
+	// resolutionLocal is an account hosted on the configured server.
+	resolutionLocal = "local"
+	// resolutionCache is a remote account the configured server already knew.
+	resolutionCache = "cache"
+	// resolutionRemote is a remote account fetched from its home server.
+	resolutionRemote = "remote"
+)
+
+// account mirrors the account entity, including the fields the mastodon
+// library does not decode.
+type account struct {
+	mastodon.Account
+	LastStatusAt *string `json:"last_status_at"`
+	Suspended    *bool   `json:"suspended"`
+	Limited      *bool   `json:"limited"`
 }
 
 func (d *AccountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -44,12 +110,26 @@ func (d *AccountDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				MarkdownDescription: "The username of the account to lookup. This should include the domain.",
-				Optional:            false,
-				Required:            true,
+				MarkdownDescription: "The username of the account to lookup. This should include the domain; accounts local to the configured server resolve the same with or without it. Exactly one of `username`, `id`, or `url` must be set.",
+				Computed:            true,
+				Optional:            true,
+				Required:            false,
 			},
 			"id": schema.StringAttribute{
-				MarkdownDescription: "A unique account identifier retrieved from the server.",
+				MarkdownDescription: "A unique account identifier retrieved from the server. Can also be set to look the account up by ID.",
+				Computed:            true,
+				Optional:            true,
+				Required:            false,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The profile URL of the account to lookup, e.g. `https://hachyderm.io/@tedivm`. The account is resolved through the server's search, fetching it from its home server if needed. " +
+					"When the account is looked up another way, this is set to the profile URL the server reports.",
+				Computed: true,
+				Optional: true,
+				Required: false,
+			},
+			"acct": schema.StringAttribute{
+				MarkdownDescription: "The handle of the account as the configured server returns it: the bare username for local accounts, or `username@domain` for remote accounts.",
 				Computed:            true,
 				Optional:            false,
 				Required:            false,
@@ -78,22 +158,193 @@ func (d *AccountDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Optional:            false,
 				Required:            false,
 			},
+			"avatar": schema.StringAttribute{
+				MarkdownDescription: "URL of the account's avatar, which may be animated.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"avatar_static": schema.StringAttribute{
+				MarkdownDescription: "URL of a static (non-animated) version of the account's avatar.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"header": schema.StringAttribute{
+				MarkdownDescription: "URL of the account's header image, which may be animated.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"header_static": schema.StringAttribute{
+				MarkdownDescription: "URL of a static (non-animated) version of the account's header image.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the account was created, as an RFC3339 timestamp.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"last_status_at": schema.StringAttribute{
+				MarkdownDescription: "When the account last posted, as an RFC3339 timestamp. Mastodon only reports the day, so the time is midnight UTC. Null if the account has never posted.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"suspended": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has been suspended by moderators. Null when the server does not report it; Mastodon only reports it for suspended accounts.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"limited": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has been limited (silenced) by moderators. Null when the server does not report it; Mastodon only reports it for limited accounts.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"followers_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of accounts following the account, as reported by the configured server. Counts for remote accounts may be out of date.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"following_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of accounts the account follows, as reported by the configured server.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"statuses_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of posts the account has made, as reported by the configured server.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"resolved": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account could be resolved. A remote account that its home server does not return, because it is down, defederated or gone, " +
+					"is reported as unresolved with every other attribute null, so configurations can skip it. A missing local account is still an error.",
+				Computed: true,
+				Optional: false,
+				Required: false,
+			},
+			"resolution_source": schema.StringAttribute{
+				MarkdownDescription: "How the account was resolved: `local` for accounts on the configured server, `cache` for remote accounts the server already knew, " +
+					"or `remote` for remote accounts fetched from their home server during the lookup. Null when the account could not be resolved.",
+				Computed: true,
+				Optional: false,
+				Required: false,
+			},
+			"include_relationship": schema.BoolAttribute{
+				MarkdownDescription: "Whether to also read the authenticated account's relationship with the account into `following`, `followed_by` and `requested`. This takes an extra request, so it defaults to `false`.",
+				Computed:            false,
+				Optional:            true,
+				Required:            false,
+			},
+			"following": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account follows the account. Null unless `include_relationship` is `true`.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"followed_by": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account follows the authenticated account. Null unless `include_relationship` is `true`.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"requested": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account has a pending follow request for the account. Null unless `include_relationship` is `true`.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+			},
+			"fields": schema.ListNestedAttribute{
+				MarkdownDescription: "The metadata fields shown on the account's profile, in the order they are displayed.",
+				Computed:            true,
+				Optional:            false,
+				Required:            false,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The label of the field.",
+							Computed:            true,
+							Optional:            false,
+							Required:            false,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the field, with HTML tags stripped.",
+							Computed:            true,
+							Optional:            false,
+							Required:            false,
+						},
+						"verified_at": schema.StringAttribute{
+							MarkdownDescription: "When the link in `value` was verified to link back to the profile, as an RFC3339 timestamp. Null if the field is not verified.",
+							Computed:            true,
+							Optional:            false,
+							Required:            false,
+						},
+					},
+				},
+			},
+			"emojis": schema.ListNestedAttribute{
+				MarkdownDescription: "The custom emoji used in the account's display name and note, in the order the server returns them. " +
+					"They appear in the text as `:shortcode:` and can be substituted with their images when rendering the profile.",
+				Computed: true,
+				Optional: false,
+				Required: false,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"shortcode": schema.StringAttribute{
+							MarkdownDescription: "The name of the emoji, without the surrounding colons.",
+							Computed:            true,
+							Optional:            false,
+							Required:            false,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The URL of the emoji image, which may be animated.",
+							Computed:            true,
+							Optional:            false,
+							Required:            false,
+						},
+						"static_url": schema.StringAttribute{
+							MarkdownDescription: "The URL of a static version of the emoji image.",
+							Computed:            true,
+							Optional:            false,
+							Required:            false,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+func (d *AccountDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("username"),
+			path.MatchRoot("id"),
+			path.MatchRoot("url"),
+		),
+	}
+}
+
 func (d *AccountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*mastodon.Client)
+	client, ok := req.ProviderData.(*MastodonClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *mastodon.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -114,7 +365,12 @@ func (d *AccountDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	account, err := d.client.AccountLookup(ctx, data.Username.ValueString())
+	if !data.Username.IsNull() {
+		tflog.Debug(ctx, "looking up account", map[string]interface{}{"handle": d.client.logHandle(data.Username.ValueString())})
+	}
+
+	var account account
+	source, err := d.findAccount(ctx, &data, &account)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to lookup account",
@@ -123,11 +379,37 @@ func (d *AccountDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	data.Id = types.StringValue(string(account.ID))
-	data.DisplayName = types.StringValue(account.DisplayName)
-	data.Note = types.StringValue(account.Note)
-	data.Locked = types.BoolValue(account.Locked)
-	data.Bot = types.BoolValue(account.Bot)
+	if source == "" {
+		tflog.Debug(ctx, "remote account could not be resolved")
+		data.setUnresolved()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	data.setAccount(&account)
+	data.Resolved = types.BoolValue(true)
+	data.ResolutionSource = types.StringValue(source)
+
+	if data.IncludeRelationship.ValueBool() {
+		relationships, err := d.client.GetAccountRelationships(ctx, []string{string(account.ID)})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read relationship",
+				fmt.Sprintf("Failed to read relationship with account %s: %s", account.ID, err),
+			)
+			return
+		}
+		if len(relationships) == 0 {
+			resp.Diagnostics.AddError(
+				"Failed to read relationship",
+				fmt.Sprintf("The server returned no relationship for account %s.", account.ID),
+			)
+			return
+		}
+		data.setRelationship(relationships[0])
+	} else {
+		data.setRelationship(nil)
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -136,3 +418,205 @@ func (d *AccountDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// setAccount maps an account entity onto the model.
+func (data *AccountDataSourceModel) setAccount(account *account) {
+	if data.Username.IsNull() {
+		data.Username = types.StringValue(account.Acct)
+	}
+	if data.Url.IsNull() {
+		data.Url = stringValueOrNull(account.URL)
+	}
+
+	data.Id = types.StringValue(string(account.ID))
+	data.Acct = types.StringValue(account.Acct)
+	data.DisplayName = types.StringValue(account.DisplayName)
+	data.Note = types.StringValue(account.Note)
+	data.Locked = types.BoolValue(account.Locked)
+	data.Bot = types.BoolValue(account.Bot)
+	data.Avatar = stringValueOrNull(account.Avatar)
+	data.AvatarStatic = stringValueOrNull(account.AvatarStatic)
+	data.Header = stringValueOrNull(account.Header)
+	data.HeaderStatic = stringValueOrNull(account.HeaderStatic)
+
+	data.Fields = newAccountFieldModels(account.Fields)
+	data.Emojis = newAccountEmojiModels(account.Emojis)
+
+	data.CreatedAt = timeValueOrNull(account.CreatedAt)
+	data.LastStatusAt = lastStatusAtValue(account.LastStatusAt)
+
+	data.Suspended = types.BoolPointerValue(account.Suspended)
+	data.Limited = types.BoolPointerValue(account.Limited)
+
+	data.FollowersCount = types.Int64Value(account.FollowersCount)
+	data.FollowingCount = types.Int64Value(account.FollowingCount)
+	data.StatusesCount = types.Int64Value(account.StatusesCount)
+}
+
+// lastStatusAtValue maps the day an account last posted to an RFC3339
+// timestamp. Older servers report a full timestamp rather than a day, and
+// accounts that have never posted have none.
+func lastStatusAtValue(value *string) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if lastStatusAt, err := time.Parse(layout, *value); err == nil {
+			return timeValueOrNull(lastStatusAt)
+		}
+	}
+	return types.StringValue(*value)
+}
+
+// setRelationship maps the authenticated account's relationship with the
+// account onto the model, leaving the flags null without one.
+func (data *AccountDataSourceModel) setRelationship(rel *mastodon.Relationship) {
+	if rel == nil {
+		data.Following = types.BoolNull()
+		data.FollowedBy = types.BoolNull()
+		data.Requested = types.BoolNull()
+		return
+	}
+
+	data.Following = types.BoolValue(rel.Following)
+	data.FollowedBy = types.BoolValue(rel.FollowedBy)
+	data.Requested = types.BoolValue(rel.Requested)
+}
+
+// setUnresolved clears the model for a remote account that could not be
+// resolved.
+func (data *AccountDataSourceModel) setUnresolved() {
+	data.Id = types.StringNull()
+	data.Acct = types.StringNull()
+	data.DisplayName = types.StringNull()
+	data.Note = types.StringNull()
+	data.Locked = types.BoolNull()
+	data.Bot = types.BoolNull()
+	data.Avatar = types.StringNull()
+	data.AvatarStatic = types.StringNull()
+	data.Header = types.StringNull()
+	data.HeaderStatic = types.StringNull()
+	data.CreatedAt = types.StringNull()
+	data.LastStatusAt = types.StringNull()
+	data.Fields = nil
+	data.Emojis = nil
+	data.Suspended = types.BoolNull()
+	data.Limited = types.BoolNull()
+	data.FollowersCount = types.Int64Null()
+	data.FollowingCount = types.Int64Null()
+	data.StatusesCount = types.Int64Null()
+	data.Resolved = types.BoolValue(false)
+	data.ResolutionSource = types.StringNull()
+	data.setRelationship(nil)
+}
+
+// findAccount fetches the account the model looks up and reports how it was
+// resolved. An empty source without an error means a remote account that
+// could not be resolved.
+func (d *AccountDataSource) findAccount(ctx context.Context, data *AccountDataSourceModel, account *account) (string, error) {
+	// The mastodon library drops `last_status_at`, so accounts are fetched
+	// directly.
+	switch {
+	case !data.Id.IsNull():
+		err := d.client.doAPI(ctx, http.MethodGet, "/api/v1/accounts/"+url.PathEscape(data.Id.ValueString()), nil, account)
+		return accountResolution(account.Acct), err
+	case !data.Url.IsNull():
+		resolved, source, err := d.resolveAccountURL(ctx, data.Url.ValueString())
+		if err != nil || resolved == nil {
+			return "", err
+		}
+		err = d.client.doAPI(ctx, http.MethodGet, "/api/v1/accounts/"+url.PathEscape(string(resolved.ID)), nil, account)
+		return source, err
+	}
+
+	handle := d.client.normalizeHandle(data.Username.ValueString())
+	err := d.client.doAPI(ctx, http.MethodGet, "/api/v1/accounts/lookup", url.Values{"acct": {handle}}, account)
+	if err == nil {
+		return accountResolution(account.Acct), nil
+	}
+	if !isNotFound(err) || !strings.Contains(handle, "@") {
+		return "", err
+	}
+
+	// The server does not know the remote account yet, so it is fetched
+	// from its home server.
+	results, err := d.client.Search(ctx, "@"+handle, true)
+	if err != nil {
+		return "", err
+	}
+	for _, result := range results.Accounts {
+		if strings.EqualFold(result.Acct, handle) {
+			err := d.client.doAPI(ctx, http.MethodGet, "/api/v1/accounts/"+url.PathEscape(string(result.ID)), nil, account)
+			return resolutionRemote, err
+		}
+	}
+	return "", nil
+}
+
+// resolveAccountURL resolves a profile URL to an account, fetching it from its
+// home server only when the configured server does not know it already. A nil
+// account means the URL could not be resolved.
+func (d *AccountDataSource) resolveAccountURL(ctx context.Context, profileURL string) (*mastodon.Account, string, error) {
+	results, err := d.client.Search(ctx, profileURL, false)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(results.Accounts) == 1 {
+		return results.Accounts[0], accountResolution(results.Accounts[0].Acct), nil
+	}
+
+	results, err = d.client.Search(ctx, profileURL, true)
+	if err != nil {
+		return nil, "", err
+	}
+	switch len(results.Accounts) {
+	case 0:
+		return nil, "", nil
+	case 1:
+		return results.Accounts[0], resolutionRemote, nil
+	default:
+		return nil, "", fmt.Errorf("expected %q to resolve to exactly one account, got %d", profileURL, len(results.Accounts))
+	}
+}
+
+// accountResolution tells local accounts, whose handle has no domain, from
+// remote accounts the server already knew.
+func accountResolution(acct string) string {
+	if strings.Contains(acct, "@") {
+		return resolutionCache
+	}
+	return resolutionLocal
+}
+
+// newAccountEmojiModels maps custom emoji onto the model, keeping their order.
+func newAccountEmojiModels(emojis []mastodon.Emoji) []AccountEmojiModel {
+	models := make([]AccountEmojiModel, 0, len(emojis))
+	for _, emoji := range emojis {
+		models = append(models, AccountEmojiModel{
+			Shortcode: types.StringValue(emoji.ShortCode),
+			Url:       types.StringValue(emoji.URL),
+			StaticUrl: types.StringValue(emoji.StaticURL),
+		})
+	}
+	return models
+}
+
+// newAccountFieldModels maps profile metadata fields onto the model, keeping
+// their order.
+func newAccountFieldModels(fields []mastodon.Field) []AccountFieldModel {
+	p := bluemonday.NewPolicy()
+
+	models := make([]AccountFieldModel, 0, len(fields))
+	for _, field := range fields {
+		model := AccountFieldModel{
+			Name:       types.StringValue(field.Name),
+			Value:      types.StringValue(p.Sanitize(field.Value)),
+			VerifiedAt: types.StringNull(),
+		}
+		if !field.VerifiedAt.IsZero() {
+			model.VerifiedAt = types.StringValue(field.VerifiedAt.UTC().Format(time.RFC3339))
+		}
+		models = append(models, model)
+	}
+	return models
+}
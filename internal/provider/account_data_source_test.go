@@ -1,9 +1,17 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccAccountDataSource(t *testing.T) {
@@ -16,6 +24,28 @@ func TestAccAccountDataSource(t *testing.T) {
 				Config: testAccAccountDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.mastodon_account.test", "bot", "false"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "avatar"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "avatar_static"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "header"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "header_static"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "created_at"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "last_status_at"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "fields.0.name"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "fields.0.verified_at"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "followers_count"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "following_count"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "statuses_count"),
+					resource.TestCheckNoResourceAttr("data.mastodon_account.test", "following"),
+					resource.TestCheckResourceAttr("data.mastodon_account.test", "resolved", "true"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "resolution_source"),
+				),
+			},
+			{
+				Config: testAccAccountDataSourceRelationshipConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "following"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "followed_by"),
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "requested"),
 				),
 			},
 		},
@@ -27,3 +57,238 @@ data "mastodon_account" "test" {
   username = "tedivm@hachyderm.io"
 }
 `
+
+const testAccAccountDataSourceRelationshipConfig = `
+data "mastodon_account" "test" {
+  username             = "tedivm@hachyderm.io"
+  include_relationship = true
+}
+`
+
+func TestAccAccountDataSource_URL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAccountDataSourceURLConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_account.test", "id"),
+					resource.TestCheckResourceAttrPair("data.mastodon_account.test", "id", "data.mastodon_account.by_username", "id"),
+					resource.TestCheckResourceAttrPair("data.mastodon_account.test", "acct", "data.mastodon_account.by_username", "acct"),
+					resource.TestCheckResourceAttr("data.mastodon_account.by_username", "url", "https://hachyderm.io/@tedivm"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAccountDataSourceURLConfig = `
+data "mastodon_account" "test" {
+  url = "https://hachyderm.io/@tedivm"
+}
+
+data "mastodon_account" "by_username" {
+  username = "tedivm@hachyderm.io"
+}
+`
+
+func TestNewAccountFieldModels(t *testing.T) {
+	models := newAccountFieldModels([]mastodon.Field{
+		{
+			Name:       "Website",
+			Value:      `<a href="https://tedivm.com" rel="nofollow noopener me" target="_blank"><span class="invisible">https://</span><span class="">tedivm.com</span></a>`,
+			VerifiedAt: time.Date(2024, 5, 6, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			Name:  "Pronouns",
+			Value: "they/them",
+		},
+	})
+
+	assert.Equal(t, []AccountFieldModel{
+		{Name: types.StringValue("Website"), Value: types.StringValue("https://tedivm.com"), VerifiedAt: types.StringValue("2024-05-06T12:30:00Z")},
+		{Name: types.StringValue("Pronouns"), Value: types.StringValue("they/them"), VerifiedAt: types.StringNull()},
+	}, models)
+}
+
+func TestAccountDataSourceModel_ModerationFlags(t *testing.T) {
+	var flagged account
+	err := json.Unmarshal([]byte(`{"id":"1","username":"spammer","acct":"spammer@spam.example","suspended":true,"limited":false,"last_status_at":null}`), &flagged)
+	assert.NoError(t, err)
+
+	var data AccountDataSourceModel
+	data.Username = types.StringNull()
+	data.setAccount(&flagged)
+	assert.Equal(t, "spammer@spam.example", data.Username.ValueString())
+	assert.True(t, data.Suspended.ValueBool())
+	assert.False(t, data.Limited.ValueBool())
+	assert.True(t, data.LastStatusAt.IsNull())
+	assert.True(t, data.CreatedAt.IsNull())
+
+	// Servers that do not surface moderation flags leave them null.
+	var plain account
+	err = json.Unmarshal([]byte(`{"id":"2","username":"tedivm","acct":"tedivm"}`), &plain)
+	assert.NoError(t, err)
+
+	data = AccountDataSourceModel{Username: types.StringNull()}
+	data.setAccount(&plain)
+	assert.True(t, data.Suspended.IsNull())
+	assert.True(t, data.Limited.IsNull())
+}
+
+func TestAccountDataSourceModel_URLAndAcct(t *testing.T) {
+	var local account
+	err := json.Unmarshal([]byte(`{"id":"1","username":"tedivm","acct":"tedivm","url":"https://hachyderm.io/@tedivm"}`), &local)
+	assert.NoError(t, err)
+
+	data := AccountDataSourceModel{Username: types.StringValue("tedivm@hachyderm.io"), Url: types.StringNull()}
+	data.setAccount(&local)
+	assert.Equal(t, types.StringValue("tedivm"), data.Acct)
+	assert.Equal(t, types.StringValue("https://hachyderm.io/@tedivm"), data.Url)
+
+	// A configured URL is kept as it was written.
+	data = AccountDataSourceModel{Username: types.StringNull(), Url: types.StringValue("https://hachyderm.io/@TedIVM")}
+	data.setAccount(&local)
+	assert.Equal(t, types.StringValue("https://hachyderm.io/@TedIVM"), data.Url)
+
+	data.setUnresolved()
+	assert.True(t, data.Acct.IsNull())
+}
+
+func TestAccountDataSourceModel_Counts(t *testing.T) {
+	var counted account
+	err := json.Unmarshal([]byte(`{"id":"1","username":"tedivm","acct":"tedivm","followers_count":1200,"following_count":340,"statuses_count":5678}`), &counted)
+	assert.NoError(t, err)
+
+	data := AccountDataSourceModel{Username: types.StringNull()}
+	data.setAccount(&counted)
+	assert.Equal(t, types.Int64Value(1200), data.FollowersCount)
+	assert.Equal(t, types.Int64Value(340), data.FollowingCount)
+	assert.Equal(t, types.Int64Value(5678), data.StatusesCount)
+
+	data.setUnresolved()
+	assert.True(t, data.FollowersCount.IsNull())
+	assert.True(t, data.FollowingCount.IsNull())
+	assert.True(t, data.StatusesCount.IsNull())
+}
+
+func TestLastStatusAtValue(t *testing.T) {
+	day := "2024-05-06"
+	timestamp := "2019-11-24T15:49:42.251Z"
+	empty := ""
+
+	assert.Equal(t, types.StringValue("2024-05-06T00:00:00Z"), lastStatusAtValue(&day))
+	assert.Equal(t, types.StringValue("2019-11-24T15:49:42Z"), lastStatusAtValue(&timestamp))
+	assert.True(t, lastStatusAtValue(&empty).IsNull())
+	assert.True(t, lastStatusAtValue(nil).IsNull())
+}
+
+func TestAccountDataSourceModel_SetRelationship(t *testing.T) {
+	var data AccountDataSourceModel
+	data.setRelationship(&mastodon.Relationship{ID: "1", Following: true, Requested: false, FollowedBy: true})
+	assert.Equal(t, types.BoolValue(true), data.Following)
+	assert.Equal(t, types.BoolValue(true), data.FollowedBy)
+	assert.Equal(t, types.BoolValue(false), data.Requested)
+
+	data.setRelationship(nil)
+	assert.True(t, data.Following.IsNull())
+	assert.True(t, data.FollowedBy.IsNull())
+	assert.True(t, data.Requested.IsNull())
+}
+
+func TestAccountDataSource_FindAccount(t *testing.T) {
+	accounts := map[string]string{
+		"1": `{"id":"1","username":"me","acct":"me"}`,
+		"2": `{"id":"2","username":"tedivm","acct":"tedivm@hachyderm.io"}`,
+		"3": `{"id":"3","username":"new","acct":"new@remote.example"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/accounts/lookup" && r.URL.Query().Get("acct") == "me":
+			_, _ = w.Write([]byte(accounts["1"]))
+		case r.URL.Path == "/api/v1/accounts/lookup" && r.URL.Query().Get("acct") == "tedivm@hachyderm.io":
+			_, _ = w.Write([]byte(accounts["2"]))
+		case r.URL.Path == "/api/v2/search" && r.URL.Query().Get("q") == "@new@remote.example" && r.URL.Query().Get("resolve") == "true":
+			_, _ = w.Write([]byte(`{"accounts":[` + accounts["3"] + `],"statuses":[],"hashtags":[]}`))
+		case r.URL.Path == "/api/v2/search":
+			_, _ = w.Write([]byte(`{"accounts":[],"statuses":[],"hashtags":[]}`))
+		case r.URL.Path == "/api/v1/accounts/3":
+			_, _ = w.Write([]byte(accounts["3"]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	d := &AccountDataSource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		currentUser: &mastodon.Account{ID: "1", Acct: "me", URL: "https://localhost/@me"},
+	}}
+
+	for _, tc := range []struct {
+		username string
+		source   string
+		id       mastodon.ID
+	}{
+		{"me@localhost", resolutionLocal, "1"},
+		{"tedivm@hachyderm.io", resolutionCache, "2"},
+		{"@new@remote.example", resolutionRemote, "3"},
+		{"gone@down.example", "", ""},
+	} {
+		var found account
+		source, err := d.findAccount(context.Background(), &AccountDataSourceModel{
+			Username: types.StringValue(tc.username),
+			Id:       types.StringNull(),
+			Url:      types.StringNull(),
+		}, &found)
+		assert.NoError(t, err, tc.username)
+		assert.Equal(t, tc.source, source, tc.username)
+		assert.Equal(t, tc.id, found.ID, tc.username)
+	}
+
+	// A missing local account is an error rather than an unresolved account.
+	var found account
+	_, err := d.findAccount(context.Background(), &AccountDataSourceModel{
+		Username: types.StringValue("missing"),
+		Id:       types.StringNull(),
+		Url:      types.StringNull(),
+	}, &found)
+	assert.True(t, isNotFound(err))
+}
+
+func TestAccountDataSourceModel_Emojis(t *testing.T) {
+	var profile account
+	err := json.Unmarshal([]byte(`{
+		"id": "3",
+		"username": "blobfan",
+		"acct": "blobfan@blob.example",
+		"display_name": "Blob Fan :blobcat: :blobfox:",
+		"note": "<p>I like :blobcat:</p>",
+		"emojis": [
+			{"shortcode": "blobcat", "url": "https://blob.example/emoji/blobcat.gif", "static_url": "https://blob.example/emoji/static/blobcat.png", "visible_in_picker": true},
+			{"shortcode": "blobfox", "url": "https://blob.example/emoji/blobfox.png", "static_url": "https://blob.example/emoji/static/blobfox.png", "visible_in_picker": true}
+		]
+	}`), &profile)
+	assert.NoError(t, err)
+
+	data := AccountDataSourceModel{Username: types.StringNull()}
+	data.setAccount(&profile)
+	assert.Equal(t, []AccountEmojiModel{
+		{Shortcode: types.StringValue("blobcat"), Url: types.StringValue("https://blob.example/emoji/blobcat.gif"), StaticUrl: types.StringValue("https://blob.example/emoji/static/blobcat.png")},
+		{Shortcode: types.StringValue("blobfox"), Url: types.StringValue("https://blob.example/emoji/blobfox.png"), StaticUrl: types.StringValue("https://blob.example/emoji/static/blobfox.png")},
+	}, data.Emojis)
+
+	// Profiles without custom emoji map to an empty list rather than null.
+	var plain account
+	err = json.Unmarshal([]byte(`{"id":"2","username":"tedivm","acct":"tedivm","emojis":[]}`), &plain)
+	assert.NoError(t, err)
+
+	data = AccountDataSourceModel{Username: types.StringNull()}
+	data.setAccount(&plain)
+	assert.NotNil(t, data.Emojis)
+	assert.Empty(t, data.Emojis)
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileAppender appends to a file shared by resources that are destroyed in
+// parallel. Writes are serialized and every one is made with a single call,
+// so the entries of concurrent writers never interleave.
+type fileAppender struct {
+	mu   sync.Mutex
+	path string
+}
+
+var (
+	fileAppenders   = map[string]*fileAppender{}
+	fileAppendersMu sync.Mutex
+)
+
+// appenderFor returns the appender of the file. Every writer of a file, such
+// as the archives of aliased providers configured with the same path, shares
+// one appender so that their writes are serialized too.
+func appenderFor(path string) *fileAppender {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	fileAppendersMu.Lock()
+	defer fileAppendersMu.Unlock()
+	a, ok := fileAppenders[path]
+	if !ok {
+		a = &fileAppender{path: path}
+		fileAppenders[path] = a
+	}
+	return a
+}
+
+// append writes the data to the end of the file, creating it when it does not
+// exist yet.
+func (a *fileAppender) append(data []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppenderFor(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "archive.jsonl")
+
+	// Writers of the same file share an appender, even when the path is
+	// spelled differently.
+	archive := newPostArchive(path)
+	assert.Same(t, archive.file, newPostArchive(filepath.Join(dir, ".", "archive.jsonl")).file)
+	assert.Same(t, archive.file, newImportBlockWriter(path).file)
+
+	assert.NotSame(t, archive.file, newPostArchive(filepath.Join(dir, "other.jsonl")).file)
+}
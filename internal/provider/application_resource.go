@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// outOfBandRedirectURI is the redirect URI of applications that show the
+// authorization code to the user instead of redirecting.
+const outOfBandRedirectURI = "urn:ietf:wg:oauth:2.0:oob"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApplicationResource{}
+
+func NewApplicationResource() resource.Resource {
+	return &ApplicationResource{}
+}
+
+// ApplicationResource defines the resource implementation.
+type ApplicationResource struct {
+	client *MastodonClient
+}
+
+// ApplicationResourceModel describes the resource data model.
+type ApplicationResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	ClientName   types.String `tfsdk:"client_name"`
+	RedirectUris types.List   `tfsdk:"redirect_uris"`
+	Scopes       types.List   `tfsdk:"scopes"`
+	Website      types.String `tfsdk:"website"`
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+func (r *ApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application"
+}
+
+func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource registers an OAuth application on the configured server, whose credentials can be used to configure the provider elsewhere. " +
+			"Mastodon has no API to change or delete applications, so every change registers a new one and destroying it only removes it from the Terraform state. " +
+			"The application cannot be imported, as the server never returns its secret again.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the application.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the application, shown on posts made with it.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"redirect_uris": schema.ListAttribute{
+				MarkdownDescription: "URIs users may be redirected to after authorizing the application. Defaults to `[\"" + outOfBandRedirectURI + "\"]`, which shows the authorization code instead of redirecting.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue(outOfBandRedirectURI)})),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "Scopes the application may request, e.g. `read`, `write:statuses` or `follow`. Defaults to `[\"read\"]`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("read")})),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"website": schema.StringAttribute{
+				MarkdownDescription: "URL of the application's homepage.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID of the application.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "Client secret of the application.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ApplicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ApplicationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var redirectUris, scopes []string
+	resp.Diagnostics.Append(data.RedirectUris.ElementsAs(ctx, &redirectUris, false)...)
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping application registration.")
+		data.Id = types.StringValue(validateOnlyID)
+		data.ClientId = types.StringValue(validateOnlyID)
+		data.ClientSecret = types.StringValue(validateOnlyID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Registering with the provider's HTTP client keeps its proxy, TLS and
+	// retry settings.
+	app, err := mastodon.RegisterApp(ctx, &mastodon.AppConfig{
+		Client:       r.client.Client.Client,
+		Server:       r.client.Config.Server,
+		ClientName:   data.ClientName.ValueString(),
+		RedirectURIs: strings.Join(redirectUris, "\n"),
+		Scopes:       strings.Join(scopes, " "),
+		Website:      data.Website.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to register application, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(string(app.ID))
+	data.ClientId = types.StringValue(app.ClientID)
+	data.ClientSecret = types.StringValue(app.ClientSecret)
+
+	tflog.Trace(ctx, "registered an application")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Applications can only be read with their own access token, so the
+	// state is kept as registered.
+	var data ApplicationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data ApplicationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ApplicationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Application Not Deleted",
+		fmt.Sprintf("Mastodon has no API to delete applications, so application %s was only removed from the Terraform state. "+
+			"Its credentials remain valid on the server.", data.Id.ValueString()),
+	)
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccApplicationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mastodon_application.test", "id"),
+					resource.TestCheckResourceAttrSet("mastodon_application.test", "client_id"),
+					resource.TestCheckResourceAttrSet("mastodon_application.test", "client_secret"),
+					resource.TestCheckResourceAttr("mastodon_application.test", "redirect_uris.0", outOfBandRedirectURI),
+					resource.TestCheckResourceAttr("mastodon_application.test", "scopes.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccApplicationResourceConfig = `
+resource "mastodon_application" "test" {
+  client_name = "Terraform Acceptance Test"
+  scopes      = ["read", "write:statuses"]
+}
+`
+
+func TestApplicationResource_Create(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/apps" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = r.ParseForm()
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"42","name":"Announcer","redirect_uri":"urn:ietf:wg:oauth:2.0:oob","client_id":"the-id","client_secret":"the-secret"}`))
+	}))
+	defer server.Close()
+
+	r := &ApplicationResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags := plan.Set(context.Background(), &ApplicationResourceModel{
+		Id:         types.StringUnknown(),
+		ClientName: types.StringValue("Announcer"),
+		RedirectUris: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("https://example.com/callback"),
+			types.StringValue(outOfBandRedirectURI),
+		}),
+		Scopes:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("read"), types.StringValue("write:statuses")}),
+		Website:      types.StringNull(),
+		ClientId:     types.StringUnknown(),
+		ClientSecret: types.StringUnknown(),
+	})
+	assert.False(t, diags.HasError(), diags)
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: plan}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	assert.Equal(t, map[string]string{
+		"client_name":   "Announcer",
+		"redirect_uris": "https://example.com/callback\n" + outOfBandRedirectURI,
+		"scopes":        "read write:statuses",
+		"website":       "",
+	}, form)
+
+	var data ApplicationResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	assert.Equal(t, types.StringValue("42"), data.Id)
+	assert.Equal(t, types.StringValue("the-id"), data.ClientId)
+	assert.Equal(t, types.StringValue("the-secret"), data.ClientSecret)
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"encoding/json"
+)
+
+// postArchive appends the content of destroyed posts to a JSON Lines file so
+// there is an audit trail of everything Terraform removed.
+type postArchive struct {
+	file *fileAppender
+}
+
+// archivedPost is a single line of the archive file.
+type archivedPost struct {
+	Id          string `json:"id"`
+	CreatedAt   string `json:"created_at"`
+	Account     string `json:"account"`
+	Visibility  string `json:"visibility"`
+	SpoilerText string `json:"spoiler_text"`
+	Content     string `json:"content"`
+	DeletedAt   string `json:"deleted_at"`
+}
+
+func newPostArchive(path string) *postArchive {
+	return &postArchive{file: appenderFor(path)}
+}
+
+// write appends the post as one line.
+func (a *postArchive) write(post archivedPost) error {
+	line, err := json.Marshal(post)
+	if err != nil {
+		return err
+	}
+	return a.file.append(append(line, '\n'))
+}
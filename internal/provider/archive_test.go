@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.jsonl")
+	archive := newPostArchive(path)
+
+	const posts = 20
+
+	var wg sync.WaitGroup
+	for i := 0; i < posts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := archive.write(archivedPost{
+				Id:        fmt.Sprint(i),
+				CreatedAt: "2024-01-02T15:04:05Z",
+				Content:   fmt.Sprintf("Post number %d", i),
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	seen := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var post archivedPost
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &post))
+		seen[post.Id] = post.Content
+	}
+	assert.NoError(t, scanner.Err())
+
+	assert.Len(t, seen, posts)
+	assert.Equal(t, "Post number 7", seen["7"])
+}
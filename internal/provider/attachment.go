@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// defaultMaxMediaAttachments is the number of media attachments Mastodon
+// allows on a single post, used when the server does not advertise a limit.
+const defaultMaxMediaAttachments = 4
+
+// focusPattern matches a focal point: two coordinates between -1.0 and 1.0
+// separated by a comma.
+var focusPattern = regexp.MustCompile(`^-?(0(\.\d+)?|1(\.0+)?),-?(0(\.\d+)?|1(\.0+)?)$`)
+
+// PostAttachmentModel describes a media file uploaded along with a post.
+type PostAttachmentModel struct {
+	FilePath    types.String `tfsdk:"file_path"`
+	Description types.String `tfsdk:"description"`
+	Focus       types.String `tfsdk:"focus"`
+}
+
+// maxMediaAttachments returns the number of media attachments the server
+// allows on a single post, as advertised by the instance configuration.
+func (c *MastodonClient) maxMediaAttachments(ctx context.Context) int {
+	inst, err := c.cachedInstance(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to read the media attachment limit, assuming the default", map[string]interface{}{"error": err.Error()})
+		return defaultMaxMediaAttachments
+	}
+	return inst.maxMediaAttachments()
+}
+
+// uploadAttachments uploads the media files in order and returns their IDs.
+// If any upload fails, the media already uploaded is deleted so nothing is
+// left orphaned on the server.
+func (c *MastodonClient) uploadAttachments(ctx context.Context, attachments []PostAttachmentModel) ([]mastodon.ID, error) {
+	ids := make([]mastodon.ID, 0, len(attachments))
+	for _, attachment := range attachments {
+		id, err := c.uploadAttachment(ctx, attachment)
+		if err != nil {
+			c.deleteMedia(ctx, ids)
+			return nil, fmt.Errorf("unable to upload %s: %w", attachment.FilePath.ValueString(), err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (c *MastodonClient) uploadAttachment(ctx context.Context, attachment PostAttachmentModel) (mastodon.ID, error) {
+	f, err := os.Open(attachment.FilePath.ValueString())
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	uploaded, err := c.UploadMediaFromMedia(ctx, &mastodon.Media{
+		File:        f,
+		Description: attachment.Description.ValueString(),
+		Focus:       attachment.Focus.ValueString(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return uploaded.ID, nil
+}
+
+// deleteMedia deletes media that was uploaded but never attached to a post.
+// Failures are only logged, as the server removes unattached media on its
+// own eventually.
+func (c *MastodonClient) deleteMedia(ctx context.Context, ids []mastodon.ID) {
+	for _, id := range ids {
+		// The mastodon library cannot delete media, so the endpoint is
+		// called directly.
+		err := c.doAPI(ctx, http.MethodDelete, "/api/v1/media/"+url.PathEscape(string(id)), nil, nil)
+		if err != nil {
+			tflog.Warn(ctx, "unable to delete unattached media", map[string]interface{}{"id": string(id), "error": err.Error()})
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadAttachments_CleansUpOnFailure(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"id":"22345792","type":"image"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	ids, err := client.uploadAttachments(context.Background(), []PostAttachmentModel{
+		{FilePath: types.StringValue("testdata/pixel.png"), Description: types.StringValue("A single pixel"), Focus: types.StringNull()},
+		{FilePath: types.StringValue("testdata/missing.png"), Description: types.StringNull(), Focus: types.StringNull()},
+	})
+	assert.ErrorContains(t, err, "testdata/missing.png")
+	assert.Nil(t, ids)
+	assert.Equal(t, []string{"POST /api/v1/media", "DELETE /api/v1/media/22345792"}, requests)
+}
+
+func TestFocusPattern(t *testing.T) {
+	for _, focus := range []string{"0,0", "-1,1", "0.5,-0.25", "1.0,-1.00"} {
+		assert.True(t, focusPattern.MatchString(focus), focus)
+	}
+	for _, focus := range []string{"", "0", "1.5,0", "0, 0", "a,b", "-1.1,0"} {
+		assert.False(t, focusPattern.MatchString(focus), focus)
+	}
+}
+
+func TestMaxMediaAttachments(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uri":"fedi.example","version":"4.2.0+glitch","configuration":{"statuses":{"max_characters":5000,"max_media_attachments":8}}}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	assert.Equal(t, 8, client.maxMediaAttachments(context.Background()))
+	assert.Equal(t, 8, client.maxMediaAttachments(context.Background()))
+	assert.Equal(t, 1, requests)
+}
+
+func TestMaxMediaAttachments_Default(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uri":"fedi.example","version":"2.7.0 (compatible; Pleroma 2.5.0)"}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	assert.Equal(t, defaultMaxMediaAttachments, client.maxMediaAttachments(context.Background()))
+}
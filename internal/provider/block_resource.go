@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BlockResource{}
+var _ resource.ResourceWithImportState = &BlockResource{}
+
+func NewBlockResource() resource.Resource {
+	return &BlockResource{}
+}
+
+// BlockResource defines the resource implementation.
+type BlockResource struct {
+	client *MastodonClient
+}
+
+// BlockResourceModel describes the resource data model.
+type BlockResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	AccountId  types.String `tfsdk:"account_id"`
+	Blocking   types.Bool   `tfsdk:"blocking"`
+	Following  types.Bool   `tfsdk:"following"`
+	FollowedBy types.Bool   `tfsdk:"followed_by"`
+	Muting     types.Bool   `tfsdk:"muting"`
+
+	AccessToken types.String `tfsdk:"access_token"`
+}
+
+func (r *BlockResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_block"
+}
+
+func (r *BlockResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource blocks an account as the authenticated account, and unblocks it when destroyed. " +
+			"Blocking an account also removes any follows between the two accounts.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the block, the same as `account_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the account to block.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"blocking": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account blocks the account.",
+				Computed:            true,
+			},
+			"following": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account follows the account.",
+				Computed:            true,
+			},
+			"followed_by": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account follows the authenticated account.",
+				Computed:            true,
+			},
+			"muting": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account also mutes the account.",
+				Computed:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token of another account on the same instance to block as, instead of the provider's account. " +
+					"The provider's server and application credentials are still used. Changing it creates a new block.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BlockResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BlockResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	if client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping block.")
+		data.Id = types.StringValue(validateOnlyID)
+		data.setRelationship(nil)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	rel, err := client.AccountBlock(ctx, mastodon.ID(data.AccountId.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to block account, got error: %s", err))
+		return
+	}
+
+	data.Id = data.AccountId
+	data.setRelationship(rel)
+
+	tflog.Trace(ctx, "blocked an account")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BlockResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "block was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	rel, err := client.getRelationship(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read relationship, got error: %s", err))
+		return
+	}
+
+	if rel == nil || !rel.Blocking {
+		// The account was unblocked outside of Terraform, so the next plan
+		// blocks it again.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.AccountId = data.Id
+	data.setRelationship(&rel.Relationship)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data BlockResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BlockResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping unblock.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	_, err = client.AccountUnblock(ctx, mastodon.ID(data.Id.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unblock account, got error: %s", err))
+		return
+	}
+}
+
+func (r *BlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), req.ID)...)
+}
+
+// setRelationship maps the relationship with the blocked account onto the
+// model, setting the flags to false without one.
+func (data *BlockResourceModel) setRelationship(rel *mastodon.Relationship) {
+	if rel == nil {
+		data.Blocking = types.BoolValue(false)
+		data.Following = types.BoolValue(false)
+		data.FollowedBy = types.BoolValue(false)
+		data.Muting = types.BoolValue(false)
+		return
+	}
+
+	data.Blocking = types.BoolValue(rel.Blocking)
+	data.Following = types.BoolValue(rel.Following)
+	data.FollowedBy = types.BoolValue(rel.FollowedBy)
+	data.Muting = types.BoolValue(rel.Muting)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccBlockResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("mastodon_block.test", "id", "data.mastodon_account.test", "id"),
+					resource.TestCheckResourceAttr("mastodon_block.test", "blocking", "true"),
+					resource.TestCheckResourceAttr("mastodon_block.test", "following", "false"),
+				),
+			},
+			{
+				ResourceName:      "mastodon_block.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccBlockResourceConfig = `
+data "mastodon_account" "test" {
+  username = "tedivm@hachyderm.io"
+}
+
+resource "mastodon_block" "test" {
+  account_id = data.mastodon_account.test.id
+}
+`
+
+func TestBlockResource_ReadUnblocked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("id[]") == "7" {
+			_, _ = w.Write([]byte(`[{"id":"7","blocking":true,"muting":true}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"8","blocking":false}]`))
+	}))
+	defer server.Close()
+
+	r := &BlockResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	stateFor := func(accountID string) tfsdk.State {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		data := BlockResourceModel{Id: types.StringValue(accountID), AccountId: types.StringValue(accountID)}
+		data.setRelationship(&mastodon.Relationship{Blocking: true})
+		diags := state.Set(context.Background(), &data)
+		assert.False(t, diags.HasError(), diags)
+		return state
+	}
+
+	// The relationship is refreshed while the account is still blocked.
+	state := stateFor("7")
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var data BlockResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	assert.Equal(t, types.BoolValue(true), data.Muting)
+
+	// An account unblocked outside of Terraform is removed from the state,
+	// so the next plan blocks it again.
+	state = stateFor("8")
+	resp = &fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BookmarkResource{}
+var _ resource.ResourceWithImportState = &BookmarkResource{}
+
+func NewBookmarkResource() resource.Resource {
+	return &BookmarkResource{}
+}
+
+// BookmarkResource defines the resource implementation.
+type BookmarkResource struct {
+	client *MastodonClient
+}
+
+// BookmarkResourceModel describes the resource data model.
+type BookmarkResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	StatusId    types.String `tfsdk:"status_id"`
+	AccessToken types.String `tfsdk:"access_token"`
+}
+
+func (r *BookmarkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bookmark"
+}
+
+func (r *BookmarkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource bookmarks a status. Bookmarks are private to the account that made them.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the bookmark, the same as `status_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the status to bookmark.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token of another account on the same instance to bookmark as, instead of the provider's account. " +
+					"The provider's server and application credentials are still used. Changing it creates a new bookmark.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BookmarkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BookmarkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BookmarkResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	if client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping bookmark creation.")
+		data.Id = types.StringValue(validateOnlyID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	_, err = client.Bookmark(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to bookmark status, got error: %s", err))
+		return
+	}
+
+	data.Id = data.StatusId
+
+	tflog.Trace(ctx, "created a bookmark")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BookmarkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BookmarkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "bookmark was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	status, err := client.GetStatus(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if isNotFound(err) {
+		// The status was deleted, taking the bookmark with it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read status, got error: %s", err))
+		return
+	}
+
+	if !boolValueOrFalse(status.Bookmarked).ValueBool() {
+		// The bookmark was removed outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = data.StatusId
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BookmarkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data BookmarkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BookmarkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BookmarkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping bookmark deletion.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	_, err = client.Unbookmark(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove bookmark, got error: %s", err))
+		return
+	}
+}
+
+func (r *BookmarkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status_id"), req.ID)...)
+}
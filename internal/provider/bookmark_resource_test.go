@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBookmarkResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBookmarkResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("mastodon_bookmark.test", "id", "mastodon_post.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "mastodon_bookmark.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccBookmarkResourceConfig = `
+resource "mastodon_post" "test" {
+  content = "Bookmark Test Post"
+}
+
+resource "mastodon_bookmark" "test" {
+  status_id = mastodon_post.test.id
+}
+`
@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BoostResource{}
+var _ resource.ResourceWithImportState = &BoostResource{}
+
+func NewBoostResource() resource.Resource {
+	return &BoostResource{}
+}
+
+// BoostResource defines the resource implementation.
+type BoostResource struct {
+	client *MastodonClient
+}
+
+// BoostResourceModel describes the resource data model.
+type BoostResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	StatusId    types.String `tfsdk:"status_id"`
+	Visibility  types.String `tfsdk:"visibility"`
+	ReblogId    types.String `tfsdk:"reblog_id"`
+	AccessToken types.String `tfsdk:"access_token"`
+}
+
+func (r *BoostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_boost"
+}
+
+func (r *BoostResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource boosts (reblogs) a status, sharing it with the followers of the account. " +
+			"Only public and unlisted statuses can be boosted, along with followers-only statuses written by the account itself.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the boost, the same as `status_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the status to boost.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "The visibility of the boost: can be `public`, `unlisted`, or `private`. Defaults to the server's default, usually `public`. Changing it boosts the status again.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "unlisted", "private"),
+				},
+			},
+			"reblog_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the status the boost created, which wraps the boosted status. Distinct from `status_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token of another account on the same instance to boost as, instead of the provider's account. " +
+					"The provider's server and application credentials are still used. Changing it creates a new boost.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BoostResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BoostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BoostResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	// Statuses planned in validate_only mode do not exist to be checked.
+	if data.StatusId.ValueString() != validateOnlyID {
+		if err := client.checkBoostable(ctx, data.StatusId.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("status_id"), "Unable to Boost Status", err.Error())
+			return
+		}
+	}
+
+	if client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping boost creation.")
+		data.Id = types.StringValue(validateOnlyID)
+		data.ReblogId = types.StringValue(validateOnlyID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	reblog, err := client.reblog(ctx, data.StatusId.ValueString(), data.Visibility.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to boost status, got error: %s", err))
+		return
+	}
+
+	data.Id = data.StatusId
+	data.ReblogId = types.StringValue(string(reblog.ID))
+
+	tflog.Trace(ctx, "created a boost")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BoostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BoostResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "boost was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	status, err := client.GetStatus(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if isNotFound(err) {
+		// The status was deleted, taking the boost with it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read status, got error: %s", err))
+		return
+	}
+
+	if !boolValueOrFalse(status.Reblogged).ValueBool() {
+		// The boost was undone outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = data.StatusId
+
+	// Imported boosts do not know the status the boost created yet.
+	if data.ReblogId.IsNull() || data.ReblogId.IsUnknown() {
+		reblog, err := client.findReblog(ctx, data.StatusId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find the boost, got error: %s", err))
+			return
+		}
+		data.ReblogId = types.StringNull()
+		if reblog != nil {
+			data.ReblogId = types.StringValue(string(reblog.ID))
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BoostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data BoostResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BoostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BoostResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping boost deletion.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	_, err = client.Unreblog(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to undo boost, got error: %s", err))
+		return
+	}
+}
+
+func (r *BoostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status_id"), req.ID)...)
+}
+
+// checkBoostable returns an error when the status is private and written by
+// another account. Mastodon only lets the author boost a private status, to
+// the author's own followers.
+func (c *MastodonClient) checkBoostable(ctx context.Context, statusID string) error {
+	status, err := c.GetStatus(ctx, mastodon.ID(statusID))
+	if err != nil {
+		return fmt.Errorf("unable to read status %s: %w", statusID, err)
+	}
+
+	if status.Visibility == "private" && status.Account.ID != c.currentAccountID() {
+		return fmt.Errorf("status %s is followers-only and was written by @%s. "+
+			"Mastodon only lets the author boost a followers-only status", statusID, status.Account.Acct)
+	}
+	return nil
+}
+
+// findReblog returns the status with which the authenticated account boosted
+// a status, or nil when there is none. The boost is newer than the boosted
+// status, so only the account's statuses since then are searched.
+func (c *MastodonClient) findReblog(ctx context.Context, statusID string) (*mastodon.Status, error) {
+	pg := mastodon.Pagination{SinceID: mastodon.ID(statusID), Limit: timelinePageSize}
+	for {
+		requested := pg.MaxID
+		page, err := c.GetAccountStatuses(ctx, c.currentAccountID(), &pg)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range page {
+			if status.Reblog != nil && string(status.Reblog.ID) == statusID {
+				return status, nil
+			}
+		}
+
+		// The library leaves the pagination untouched when the response has
+		// no Link header, so an unchanged max_id means there are no more
+		// pages.
+		if len(page) == 0 || pg.MaxID == "" || pg.MaxID == requested {
+			return nil, nil
+		}
+		pg = mastodon.Pagination{MaxID: pg.MaxID, SinceID: mastodon.ID(statusID), Limit: timelinePageSize}
+	}
+}
+
+// reblog boosts a status, returning the status the boost created. The
+// mastodon library cannot set the visibility, so the endpoint is called
+// directly.
+func (c *MastodonClient) reblog(ctx context.Context, statusID string, visibility string) (*mastodon.Status, error) {
+	params := url.Values{}
+	if visibility != "" {
+		params.Set("visibility", visibility)
+	}
+
+	var status mastodon.Status
+	if err := c.doAPI(ctx, http.MethodPost, "/api/v1/statuses/"+url.PathEscape(statusID)+"/reblog", params, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccBoostResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBoostResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("mastodon_boost.test", "id", "mastodon_post.test", "id"),
+					resource.TestCheckResourceAttrSet("mastodon_boost.test", "reblog_id"),
+					resource.TestCheckResourceAttr("mastodon_boost.test", "visibility", "unlisted"),
+				),
+			},
+			{
+				ResourceName:            "mastodon_boost.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"visibility"},
+			},
+		},
+	})
+}
+
+const testAccBoostResourceConfig = `
+resource "mastodon_post" "test" {
+  content = "Boost Test Post"
+}
+
+resource "mastodon_boost" "test" {
+  status_id  = mastodon_post.test.id
+  visibility = "unlisted"
+}
+`
+
+func TestReblog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/statuses/7/reblog", r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "private", r.PostForm.Get("visibility"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"9","visibility":"private","reblog":{"id":"7","reblogged":true}}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	status, err := client.reblog(context.Background(), "7", "private")
+	assert.NoError(t, err)
+	assert.Equal(t, mastodon.ID("9"), status.ID)
+	assert.Equal(t, mastodon.ID("7"), status.Reblog.ID)
+}
+
+func TestBoostResource_CreatePrivateStatus(t *testing.T) {
+	reblogged := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/statuses/7":
+			_, _ = w.Write([]byte(`{"id":"7","visibility":"private","account":{"id":"2","acct":"alice@example.com"}}`))
+		case "/api/v1/statuses/8":
+			_, _ = w.Write([]byte(`{"id":"8","visibility":"private","account":{"id":"1","acct":"me"}}`))
+		case "/api/v1/statuses/8/reblog":
+			reblogged = true
+			_, _ = w.Write([]byte(`{"id":"9","visibility":"private","reblog":{"id":"8","reblogged":true}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &BoostResource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		currentUser: &mastodon.Account{ID: "1", Acct: "me"},
+	}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	create := func(statusID string) *fwresource.CreateResponse {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		diags := plan.Set(context.Background(), &BoostResourceModel{
+			Id:          types.StringUnknown(),
+			StatusId:    types.StringValue(statusID),
+			Visibility:  types.StringNull(),
+			ReblogId:    types.StringUnknown(),
+			AccessToken: types.StringNull(),
+		})
+		assert.False(t, diags.HasError(), diags)
+
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), fwresource.CreateRequest{Plan: plan}, resp)
+		return resp
+	}
+
+	// A followers-only status of another account cannot be boosted.
+	resp := create("7")
+	if assert.True(t, resp.Diagnostics.HasError()) {
+		assert.Equal(t, "Unable to Boost Status", resp.Diagnostics[0].Summary())
+		assert.Contains(t, resp.Diagnostics[0].Detail(), "followers-only and was written by @alice@example.com")
+	}
+	assert.False(t, reblogged)
+
+	// Its author can boost it to their followers.
+	resp = create("8")
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, reblogged)
+}
+
+func TestBoostResource_ReadFindsReblog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/statuses/7":
+			_, _ = w.Write([]byte(`{"id":"7","visibility":"public","reblogged":true}`))
+		case "/api/v1/accounts/1/statuses":
+			assert.Equal(t, "7", r.URL.Query().Get("since_id"))
+			_, _ = w.Write([]byte(`[{"id":"12","reblog":{"id":"8"}},{"id":"11","reblog":{"id":"7"}},{"id":"10"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &BoostResource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		currentUser: &mastodon.Account{ID: "1", Acct: "me"},
+	}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+
+	// An imported boost only knows the boosted status.
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags := state.Set(context.Background(), &BoostResourceModel{
+		Id:          types.StringValue("7"),
+		StatusId:    types.StringValue("7"),
+		Visibility:  types.StringNull(),
+		ReblogId:    types.StringNull(),
+		AccessToken: types.StringNull(),
+	})
+	assert.False(t, diags.HasError(), diags)
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data BoostResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	assert.Equal(t, types.StringValue("11"), data.ReblogId)
+}
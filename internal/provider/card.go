@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mattn/go-mastodon"
+)
+
+// cardAttrTypes describes the `card` object of posts and statuses.
+var cardAttrTypes = map[string]attr.Type{
+	"url":           types.StringType,
+	"title":         types.StringType,
+	"description":   types.StringType,
+	"image":         types.StringType,
+	"provider_name": types.StringType,
+}
+
+// cardValue maps the preview card of a status to an object, null when the
+// status has no card.
+func cardValue(card *mastodon.Card) types.Object {
+	if card == nil || card.URL == "" {
+		return types.ObjectNull(cardAttrTypes)
+	}
+	return types.ObjectValueMust(cardAttrTypes, map[string]attr.Value{
+		"url":           types.StringValue(card.URL),
+		"title":         types.StringValue(card.Title),
+		"description":   types.StringValue(card.Description),
+		"image":         stringValueOrNull(card.Image),
+		"provider_name": stringValueOrNull(card.ProviderName),
+	})
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardValue(t *testing.T) {
+	assert.True(t, cardValue(nil).IsNull())
+
+	card := cardValue(&mastodon.Card{
+		URL:          "https://terraformindepth.com/",
+		Title:        "Terraform in Depth",
+		Description:  "A book about Terraform.",
+		Type:         "link",
+		ProviderName: "Manning",
+	})
+	assert.Equal(t, map[string]attr.Value{
+		"url":           types.StringValue("https://terraformindepth.com/"),
+		"title":         types.StringValue("Terraform in Depth"),
+		"description":   types.StringValue("A book about Terraform."),
+		"image":         types.StringNull(),
+		"provider_name": types.StringValue("Manning"),
+	}, card.Attributes())
+}
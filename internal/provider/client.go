@@ -0,0 +1,724 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-mastodon"
+)
+
+const (
+	// defaultRetryBudget is the number of retries shared by every request made
+	// through a configured provider when `retry_budget` is not set.
+	defaultRetryBudget = 25
+
+	// defaultTimeout bounds every request made through a configured provider
+	// when `timeout` is not set.
+	defaultTimeout = 30 * time.Second
+
+	// defaultMaxRetries is the number of times a single request is retried
+	// before giving up when `max_retries` is not set.
+	defaultMaxRetries = 3
+
+	// maxRetryBackoff caps the exponential backoff between two attempts.
+	maxRetryBackoff = 30 * time.Second
+)
+
+// validateOnlyID is stored as the ID of objects that were never created
+// because the provider runs in validate only mode.
+const validateOnlyID = "validate-only"
+
+// MastodonClient is handed to resources and data sources by the provider. It
+// wraps the API client together with provider level settings.
+type MastodonClient struct {
+	*mastodon.Client
+
+	// host is the host of the configured server, e.g. `mastodon.social`.
+	host string
+
+	// currentUser is the account the provider is authenticated as.
+	currentUser *mastodon.Account
+
+	// validateOnly skips every API call that would change the server.
+	validateOnly bool
+
+	// autoCWKeywords maps keywords to the content warning applied to posts
+	// containing them.
+	autoCWKeywords map[string]string
+
+	// defaultSensitiveByVisibility is the sensitive flag used by posts that
+	// do not set one, keyed by visibility.
+	defaultSensitiveByVisibility map[string]bool
+
+	// warnLanguageMismatch warns when a post's language does not plausibly
+	// match the script of its content.
+	warnLanguageMismatch bool
+
+	// resolveMentions resolves remote mentions before posting so they
+	// federate.
+	resolveMentions bool
+
+	// truncateOverLimit truncates post content longer than the server's
+	// limit, appending truncationSuffix.
+	truncateOverLimit bool
+	truncationSuffix  string
+
+	// immutableFieldPolicy is what happens when a plan changes a post field
+	// the server cannot edit: one of immutableFieldPolicies.
+	immutableFieldPolicy string
+
+	// requireBotAccount refuses to post from accounts not flagged as bots.
+	requireBotAccount bool
+
+	// deriveAltFromFilename uses the file name as the alt text of media
+	// uploaded without a description.
+	deriveAltFromFilename bool
+
+	// redactor hashes account handles in log output when set.
+	redactor *handleRedactor
+
+	// archive records destroyed posts when `archive_on_destroy_path` is set.
+	archive *postArchive
+
+	// importBlocks records posts preserved on destroy when
+	// `generate_import_blocks_path` is set.
+	importBlocks *importBlockWriter
+
+	// mediaCache reuses media uploaded during the run when
+	// `deduplicate_media` is set.
+	mediaCache *mediaCache
+
+	// instance caches the instance entity once it has been read, and
+	// instanceFetch is the read in progress. Use cachedInstance to read
+	// them.
+	instance      *instance
+	instanceFetch *instanceFetch
+	instanceMu    sync.Mutex
+
+	// tokenClients caches the clients of resources that override the access
+	// token, keyed by token. Use forAccessToken to read it.
+	tokenClients   map[string]*tokenClient
+	tokenClientsMu sync.Mutex
+}
+
+// proxySchemes are the proxy URL schemes the HTTP transport supports.
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// parseProxyURL parses the URL of a proxy to send requests through.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(proxySchemes, u.Scheme) || u.Host == "" {
+		return nil, fmt.Errorf("expected a URL such as http://proxy.example.com:3128 or socks5://proxy.example.com:1080 with one of the schemes %v, got %q", proxySchemes, raw)
+	}
+	return u, nil
+}
+
+// caCertPool returns the system's certificate pool with the certificates of a
+// PEM bundle added, so servers signed by a private CA are trusted too.
+func caCertPool(bundle []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	found := false
+	for len(bundle) > 0 {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate: %w", err)
+		}
+		pool.AddCert(cert)
+		found = true
+	}
+	if !found {
+		return nil, errors.New("no PEM encoded certificates found")
+	}
+	return pool, nil
+}
+
+// newHTTPTransport returns the transport requests are sent with. Proxies are
+// taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+// unless proxy is set.
+func newHTTPTransport(proxy *url.URL, tlsConfig *tls.Config) *http.Transport {
+	transport := &http.Transport{}
+	if base, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = base.Clone()
+	}
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport
+}
+
+// errRetryBudgetExhausted is returned once the shared retry budget is spent.
+var errRetryBudgetExhausted = errors.New("retry budget exhausted: too many requests to the Mastodon server have been retried during this run, " +
+	"failing fast to avoid a longer rate limit penalty. Raise `retry_budget` or reduce the number of resources applied at once")
+
+// retryBudget is a token bucket shared by every request made through a
+// configured provider. Each retry takes a token and the bucket is never
+// refilled, so the aggregate number of retries across an apply stays under
+// a fixed ceiling.
+type retryBudget struct {
+	mu        sync.Mutex
+	remaining int64
+}
+
+func newRetryBudget(size int64) *retryBudget {
+	return &retryBudget{remaining: size}
+}
+
+// take consumes a token from the budget, returning false if none are left.
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining <= 0 {
+		return false
+	}
+	b.remaining--
+	return true
+}
+
+// retryTransport retries throttled (429) and failed (5xx) requests with an
+// exponential backoff, drawing every retry from a shared retryBudget. A
+// Retry-After header sent by the server takes precedence over the backoff.
+type retryTransport struct {
+	base        http.RoundTripper
+	budget      *retryBudget
+	maxRetries  int
+	baseBackoff time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, budget *retryBudget, maxRetries int) *retryTransport {
+	return &retryTransport{
+		base:        base,
+		budget:      budget,
+		maxRetries:  maxRetries,
+		baseBackoff: time.Second,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.baseBackoff
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		if attempt >= t.maxRetries {
+			// The mastodon client retries 429 responses on its own for up to
+			// an hour, so hand back an error instead of the response.
+			if resp.StatusCode == http.StatusTooManyRequests {
+				resp.Body.Close()
+				return nil, fmt.Errorf("rate limited by the Mastodon server after %d retries", attempt)
+			}
+			return resp, nil
+		}
+
+		wait := backoff
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			wait = retryAfter
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			resp.Body.Close()
+			return nil, fmt.Errorf("the Mastodon server asked to retry in %s, which is past the request timeout", wait.Round(time.Second))
+		}
+
+		if !t.budget.take() {
+			resp.Body.Close()
+			return nil, errRetryBudgetExhausted
+		}
+		resp.Body.Close()
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// timeoutTransport bounds each request, including its retries and reading the
+// response, by the provider's timeout. Unlike http.Client's timeout it starts
+// below the rate limit aware transport, so waiting for the rate limit to reset
+// does not count toward it.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func newTimeoutTransport(base http.RoundTripper, timeout time.Duration) *timeoutTransport {
+	return &timeoutTransport{base: base, timeout: timeout}
+}
+
+// noRequestTimeoutKey marks contexts of requests that are only bounded by
+// their context, such as the notification stream.
+type noRequestTimeoutKey struct{}
+
+// withoutRequestTimeout returns a context whose requests are not bounded by
+// the provider's timeout.
+func withoutRequestTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRequestTimeoutKey{}, true)
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 || req.Context().Value(noRequestTimeoutKey{}) != nil {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the timeout of a request once its response has
+// been read.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// maxErrorBodySize caps how much of an error response is read and reported.
+const maxErrorBodySize = 4096
+
+// apiErrorBody is the JSON body Mastodon sends with failed requests. OAuth
+// endpoints add an error_description to the terse error code.
+type apiErrorBody struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// apiErrorTransport rewrites error responses so the message surfaced by the
+// mastodon library, which only reads the `error` field, leads with the human
+// readable error_description and includes the raw body for debugging.
+type apiErrorTransport struct {
+	base http.RoundTripper
+}
+
+func newAPIErrorTransport(base http.RoundTripper) *apiErrorTransport {
+	return &apiErrorTransport{base: base}
+}
+
+func (t *apiErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	rewritten, err := json.Marshal(apiErrorBody{Error: formatAPIError(body)})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(rewritten))
+	resp.ContentLength = int64(len(rewritten))
+	resp.Header.Set("Content-Type", "application/json")
+	resp.Header.Del("Content-Length")
+
+	return resp, nil
+}
+
+// formatAPIError builds a readable message from an error response body. Plain
+// `{"error": "..."}` bodies are reported as is, anything richer or not JSON at
+// all is followed by the raw body.
+func formatAPIError(body []byte) string {
+	raw := strings.TrimSpace(string(body))
+
+	var e apiErrorBody
+	if err := json.Unmarshal(body, &e); err != nil || e.Error == "" {
+		if raw == "" {
+			return "the server returned no error details"
+		}
+		return "Response body: " + raw
+	}
+
+	if e.ErrorDescription == "" {
+		return e.Error
+	}
+	return fmt.Sprintf("%s (%s)\n\nResponse body: %s", e.ErrorDescription, e.Error, raw)
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// parseRetryAfter reads a Retry-After header, which is either a number of
+// seconds or an HTTP date, as the time to wait from now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := at.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// doAPI performs a request against an endpoint the mastodon library does not
+// wrap, decoding the JSON response into res when it is not nil.
+func (c *MastodonClient) doAPI(ctx context.Context, method string, endpoint string, params url.Values, res interface{}) error {
+	_, err := c.doAPIWithHeader(ctx, method, endpoint, params, nil, res)
+	return err
+}
+
+// doAPIWithHeader is doAPI with additional request headers, which also returns
+// the headers of the response.
+func (c *MastodonClient) doAPIWithHeader(ctx context.Context, method string, endpoint string, params url.Values, header http.Header, res interface{}) (http.Header, error) {
+	u, err := url.Parse(c.Config.Server)
+	if err != nil {
+		return nil, err
+	}
+	// The endpoint is an escaped path, so IDs in it are escaped by callers
+	// with url.PathEscape.
+	u = u.JoinPath(endpoint)
+
+	var body io.Reader
+	if method == http.MethodGet {
+		u.RawQuery = params.Encode()
+	} else if params != nil {
+		body = strings.NewReader(params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Config.AccessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return resp.Header, &apiStatusError{StatusCode: resp.StatusCode, Message: e.Error}
+	}
+
+	if res == nil {
+		return resp.Header, nil
+	}
+	return resp.Header, json.NewDecoder(resp.Body).Decode(res)
+}
+
+// instanceFetch is a read of the instance entity that is done once its
+// channel is closed.
+type instanceFetch struct {
+	done     chan struct{}
+	instance *instance
+	err      error
+}
+
+// cachedInstance returns the instance entity, which is read once and cached
+// for the rest of the run. Concurrent callers share one read, and a read that
+// failed is tried again by the next caller.
+func (c *MastodonClient) cachedInstance(ctx context.Context) (*instance, error) {
+	c.instanceMu.Lock()
+	if c.instance != nil {
+		defer c.instanceMu.Unlock()
+		return c.instance, nil
+	}
+	fetch := c.instanceFetch
+	if fetch == nil {
+		fetch = &instanceFetch{done: make(chan struct{})}
+		c.instanceFetch = fetch
+		// The read is shared, so it is not canceled along with the caller
+		// that started it. The provider's request timeout still applies.
+		go c.fetchInstance(context.WithoutCancel(ctx), fetch)
+	}
+	c.instanceMu.Unlock()
+
+	select {
+	case <-fetch.done:
+		return fetch.instance, fetch.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *MastodonClient) fetchInstance(ctx context.Context, fetch *instanceFetch) {
+	var inst instance
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/instance", nil, &inst)
+
+	c.instanceMu.Lock()
+	if err == nil {
+		c.instance = &inst
+		fetch.instance = &inst
+	} else {
+		fetch.err = err
+	}
+	c.instanceFetch = nil
+	c.instanceMu.Unlock()
+	close(fetch.done)
+}
+
+// tokenClient is the client of an access token, which is ready once its
+// channel is closed.
+type tokenClient struct {
+	done   chan struct{}
+	client *MastodonClient
+	err    error
+}
+
+// forAccessToken returns a client acting as the account of the access token,
+// sharing the server, application credentials, transport and settings of c.
+// The token is verified the first time it is used, and resources using the
+// same token wait for that verification rather than repeating it. A token
+// that failed to verify is tried again by the next caller. An empty token, or
+// the provider's own token, returns c.
+func (c *MastodonClient) forAccessToken(ctx context.Context, token string) (*MastodonClient, error) {
+	if token == "" || token == c.Config.AccessToken {
+		return c, nil
+	}
+
+	for {
+		c.tokenClientsMu.Lock()
+		entry, ok := c.tokenClients[token]
+		if !ok {
+			entry = &tokenClient{done: make(chan struct{})}
+			if c.tokenClients == nil {
+				c.tokenClients = map[string]*tokenClient{}
+			}
+			c.tokenClients[token] = entry
+			c.tokenClientsMu.Unlock()
+
+			// The token is verified without holding the lock, so
+			// resources using other tokens are not held up.
+			entry.client, entry.err = c.newTokenClient(ctx, token)
+			if entry.err != nil {
+				c.tokenClientsMu.Lock()
+				delete(c.tokenClients, token)
+				c.tokenClientsMu.Unlock()
+			}
+			close(entry.done)
+			return entry.client, entry.err
+		}
+		c.tokenClientsMu.Unlock()
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if entry.err == nil {
+			return entry.client, nil
+		}
+	}
+}
+
+// newTokenClient verifies the access token and returns a client acting as its
+// account.
+func (c *MastodonClient) newTokenClient(ctx context.Context, token string) (*MastodonClient, error) {
+	mc := mastodon.NewClient(&mastodon.Config{
+		Server:       c.Config.Server,
+		ClientID:     c.Config.ClientID,
+		ClientSecret: c.Config.ClientSecret,
+		AccessToken:  token,
+	})
+	// The rate limit transport keeps the limits of each token apart, so the
+	// transport can be shared.
+	mc.Transport = c.Transport
+	mc.Timeout = c.Timeout
+	mc.UserAgent = c.UserAgent
+
+	user, err := mc.GetAccountCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify the access token: %w", err)
+	}
+
+	client := &MastodonClient{
+		Client:                       mc,
+		host:                         c.host,
+		currentUser:                  user,
+		validateOnly:                 c.validateOnly,
+		autoCWKeywords:               c.autoCWKeywords,
+		defaultSensitiveByVisibility: c.defaultSensitiveByVisibility,
+		warnLanguageMismatch:         c.warnLanguageMismatch,
+		resolveMentions:              c.resolveMentions,
+		truncateOverLimit:            c.truncateOverLimit,
+		truncationSuffix:             c.truncationSuffix,
+		immutableFieldPolicy:         c.immutableFieldPolicy,
+		requireBotAccount:            c.requireBotAccount,
+		deriveAltFromFilename:        c.deriveAltFromFilename,
+		redactor:                     c.redactor,
+		archive:                      c.archive,
+		importBlocks:                 c.importBlocks,
+	}
+	if c.mediaCache != nil {
+		// Media belongs to the account that uploaded it.
+		client.mediaCache = newMediaCache()
+	}
+	return client, nil
+}
+
+// apiStatusError is returned by doAPI when the server responds with an error
+// status.
+type apiStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiStatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("bad request: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("bad request: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// isNotFound reports whether the error is a 404 response, returned either by
+// doAPI or by the mastodon library.
+func isNotFound(err error) bool {
+	var statusErr *apiStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusNotFound
+	}
+	var libraryErr *mastodon.APIError
+	if errors.As(err, &libraryErr) {
+		return libraryErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// currentAccountID returns the ID of the authenticated account, or an empty
+// ID when it is not known.
+func (c *MastodonClient) currentAccountID() mastodon.ID {
+	if c.currentUser == nil {
+		return ""
+	}
+	return c.currentUser.ID
+}
+
+// checkBotAccount returns an error when `require_bot_account` is set and the
+// authenticated account is not flagged as a bot.
+func (c *MastodonClient) checkBotAccount() error {
+	if !c.requireBotAccount || c.currentUser == nil || c.currentUser.Bot {
+		return nil
+	}
+	return fmt.Errorf("the account @%s is not flagged as a bot", c.currentUser.Acct)
+}
+
+// localDomain returns the domain of accounts local to the configured server,
+// taken from the profile URL of the authenticated account and falling back to
+// the configured host.
+func (c *MastodonClient) localDomain() string {
+	if c.currentUser != nil {
+		if u, err := url.Parse(c.currentUser.URL); err == nil && u.Host != "" {
+			return u.Host
+		}
+	}
+	if u, err := url.Parse(c.Config.Server); err == nil {
+		return u.Host
+	}
+	return ""
+}
+
+// normalizeHandle strips the leading `@` from a handle, and the domain when it
+// is the local domain, so a local account is always looked up by the same key.
+func (c *MastodonClient) normalizeHandle(handle string) string {
+	handle = strings.TrimPrefix(handle, "@")
+	username, domain, found := strings.Cut(handle, "@")
+	if found && strings.EqualFold(domain, c.localDomain()) {
+		return username
+	}
+	return handle
+}
+
+// checkLocalURL returns an error when the URL points to another instance than
+// the one the client is configured for. Each provider configuration talks to
+// a single instance, so with several aliased configurations this catches
+// objects handed to the wrong one.
+func (c *MastodonClient) checkLocalURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(u.Host, c.host) || strings.EqualFold(u.Host, c.localDomain()) {
+		return nil
+	}
+	return fmt.Errorf("%s is hosted on %s, but this provider configuration is for %s", rawURL, u.Host, c.host)
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = DurationSecondsFunction{}
+)
+
+func NewDurationSecondsFunction() function.Function {
+	return DurationSecondsFunction{}
+}
+
+type DurationSecondsFunction struct{}
+
+// durationDayWeekPattern matches the day and week components Go's duration
+// grammar lacks. None of Go's own units contain a `d` or a `w`.
+var durationDayWeekPattern = regexp.MustCompile(`([0-9]*\.?[0-9]+)([dw])`)
+
+func (r DurationSecondsFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "duration_seconds"
+}
+
+func (r DurationSecondsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Duration seconds function",
+		MarkdownDescription: "Converts a duration such as `3d12h` into a number of seconds, for attributes like poll expiry and mute durations. " +
+			"Durations use Go's duration syntax (`h`, `m`, `s`, `ms`, `us`, `ns`) extended with `d` for days and `w` for weeks. " +
+			"Fractions of a second are dropped.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "duration",
+				MarkdownDescription: "The duration to convert, e.g. `1w`, `3d12h`, or `90m`.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (r DurationSecondsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var duration string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &duration))
+
+	if resp.Error != nil {
+		return
+	}
+
+	seconds, err := parseDurationSeconds(duration)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, seconds))
+}
+
+// parseDurationSeconds parses a Go duration extended with days and weeks and
+// returns it in whole seconds. Negative durations are rejected.
+func parseDurationSeconds(duration string) (int64, error) {
+	if strings.HasPrefix(strings.TrimSpace(duration), "-") {
+		return 0, fmt.Errorf("duration %q must not be negative", duration)
+	}
+
+	var convErr error
+	expanded := durationDayWeekPattern.ReplaceAllStringFunc(duration, func(component string) string {
+		match := durationDayWeekPattern.FindStringSubmatch(component)
+		value, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			convErr = err
+			return component
+		}
+		hours := 24.0
+		if match[2] == "w" {
+			hours = 7 * 24
+		}
+		return strconv.FormatFloat(value*hours, 'f', -1, 64) + "h"
+	})
+	if convErr != nil {
+		return 0, fmt.Errorf("invalid duration %q: %s", duration, convErr)
+	}
+
+	parsed, err := time.ParseDuration(expanded)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: expected a duration such as \"3d12h\" using the units w, d, h, m, s, ms, us, or ns", duration)
+	}
+	if parsed < 0 {
+		return 0, fmt.Errorf("duration %q must not be negative", duration)
+	}
+	return int64(parsed / time.Second), nil
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationSecondsFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::mastodon::duration_seconds("3d12h")
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", "302400"),
+				),
+			},
+		},
+	})
+}
+
+func TestDurationSecondsFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::mastodon::duration_seconds("three days")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`invalid duration`),
+			},
+		},
+	})
+}
+
+func TestParseDurationSeconds(t *testing.T) {
+	for duration, expected := range map[string]int64{
+		"90s":    90,
+		"1h30m":  5400,
+		"1d":     86400,
+		"3d12h":  302400,
+		"1w":     604800,
+		"2w3d":   1468800,
+		"1.5d":   129600,
+		"0.5w1h": 306000,
+		"1500ms": 1,
+		"0s":     0,
+	} {
+		seconds, err := parseDurationSeconds(duration)
+		assert.NoError(t, err, duration)
+		assert.Equal(t, expected, seconds, duration)
+	}
+
+	for _, duration := range []string{"", "3", "three days", "-1d", "-90s", "1y", "d"} {
+		_, err := parseDurationSeconds(duration)
+		assert.Error(t, err, duration)
+	}
+}
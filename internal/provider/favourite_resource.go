@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FavouriteResource{}
+var _ resource.ResourceWithImportState = &FavouriteResource{}
+
+func NewFavouriteResource() resource.Resource {
+	return &FavouriteResource{}
+}
+
+// FavouriteResource defines the resource implementation.
+type FavouriteResource struct {
+	client *MastodonClient
+}
+
+// FavouriteResourceModel describes the resource data model.
+type FavouriteResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	StatusId        types.String `tfsdk:"status_id"`
+	FavouritesCount types.Int64  `tfsdk:"favourites_count"`
+	AccessToken     types.String `tfsdk:"access_token"`
+}
+
+func (r *FavouriteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_favourite"
+}
+
+func (r *FavouriteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource favourites a status, which notifies its author.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the favourite, the same as `status_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the status to favourite.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"favourites_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of times the status has been favourited, updated on every refresh.",
+				Computed:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token of another account on the same instance to favourite as, instead of the provider's account. " +
+					"The provider's server and application credentials are still used. Changing it creates a new favourite.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FavouriteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FavouriteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FavouriteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	if client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping favourite creation.")
+		data.Id = types.StringValue(validateOnlyID)
+		data.FavouritesCount = types.Int64Null()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	status, err := client.Favourite(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to favourite status, got error: %s", err))
+		return
+	}
+
+	data.Id = data.StatusId
+	data.FavouritesCount = types.Int64Value(status.FavouritesCount)
+
+	tflog.Trace(ctx, "created a favourite")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FavouriteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FavouriteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "favourite was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	status, err := client.GetStatus(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if isNotFound(err) {
+		// The status was deleted, taking the favourite with it.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read status, got error: %s", err))
+		return
+	}
+
+	if !boolValueOrFalse(status.Favourited).ValueBool() {
+		// The favourite was removed outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = data.StatusId
+	data.FavouritesCount = types.Int64Value(status.FavouritesCount)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FavouriteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data FavouriteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FavouriteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FavouriteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping favourite deletion.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	_, err = client.Unfavourite(ctx, mastodon.ID(data.StatusId.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove favourite, got error: %s", err))
+		return
+	}
+}
+
+func (r *FavouriteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status_id"), req.ID)...)
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccFavouriteResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFavouriteResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("mastodon_favourite.test", "id", "mastodon_post.test", "id"),
+					resource.TestCheckResourceAttr("mastodon_favourite.test", "favourites_count", "1"),
+				),
+			},
+			{
+				ResourceName:      "mastodon_favourite.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccFavouriteResourceConfig = `
+resource "mastodon_post" "test" {
+  content = "Favourite Test Post"
+}
+
+resource "mastodon_favourite" "test" {
+  status_id = mastodon_post.test.id
+}
+`
+
+func TestFavouriteResource_ReadDeletedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/statuses/7" {
+			_, _ = w.Write([]byte(`{"id":"7","favourited":true,"favourites_count":3}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+	}))
+	defer server.Close()
+
+	r := &FavouriteResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	stateFor := func(statusID string) tfsdk.State {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		diags := state.Set(context.Background(), &FavouriteResourceModel{
+			Id:              types.StringValue(statusID),
+			StatusId:        types.StringValue(statusID),
+			FavouritesCount: types.Int64Value(1),
+			AccessToken:     types.StringNull(),
+		})
+		assert.False(t, diags.HasError(), diags)
+		return state
+	}
+
+	// The count is refreshed while the status is still favourited.
+	state := stateFor("7")
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var data FavouriteResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	assert.Equal(t, types.Int64Value(3), data.FavouritesCount)
+
+	// A deleted status removes the favourite rather than failing.
+	state = stateFor("8")
+	resp = &fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+}
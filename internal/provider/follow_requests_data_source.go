@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// followRequestsPageSize is the largest page the follow requests endpoint
+// returns.
+const followRequestsPageSize = 80
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FollowRequestsDataSource{}
+
+func NewFollowRequestsDataSource() datasource.DataSource {
+	return &FollowRequestsDataSource{}
+}
+
+// FollowRequestsDataSource defines the data source implementation.
+type FollowRequestsDataSource struct {
+	client *MastodonClient
+}
+
+// FollowRequestsDataSourceModel describes the data source data model.
+type FollowRequestsDataSourceModel struct {
+	MaxId         types.String                `tfsdk:"max_id"`
+	Accounts      []FollowRequestAccountModel `tfsdk:"accounts"`
+	PendingCount  types.Int64                 `tfsdk:"pending_count"`
+	SnapshotMaxId types.String                `tfsdk:"snapshot_max_id"`
+}
+
+// FollowRequestAccountModel describes an account requesting to follow.
+type FollowRequestAccountModel struct {
+	Id          types.String `tfsdk:"id"`
+	Acct        types.String `tfsdk:"acct"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+func (d *FollowRequestsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_follow_requests"
+}
+
+func (d *FollowRequestsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads the pending requests to follow the authenticated account, which locked accounts have to authorize or reject. " +
+			"New requests may arrive between reads. To read the same requests again, set `max_id` to the `snapshot_max_id` of an earlier read. " +
+			"Requests authorized or rejected since then still drop out.",
+
+		Attributes: map[string]schema.Attribute{
+			"max_id": schema.StringAttribute{
+				MarkdownDescription: "Only return requests made before this pagination ID, e.g. the `snapshot_max_id` of an earlier read. " +
+					"Pagination IDs identify the requests, not the requesting accounts.",
+				Optional: true,
+			},
+			"snapshot_max_id": schema.StringAttribute{
+				MarkdownDescription: "The `max_id` that reads the same requests again. This is `max_id` when it is set. " +
+					"Otherwise it is a pagination ID just above the newest request, or that request's pagination ID on servers without numeric IDs, such as Pleroma. " +
+					"Null when no requests are pending or the server does not paginate them.",
+				Computed: true,
+			},
+			"accounts": schema.ListNestedAttribute{
+				MarkdownDescription: "The accounts requesting to follow, in the order the server returns them. Empty when no requests are pending.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the requesting account.",
+							Computed:            true,
+						},
+						"acct": schema.StringAttribute{
+							MarkdownDescription: "The handle of the requesting account: the username for local accounts, `username@domain` for remote ones.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the requesting account.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"pending_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of pending follow requests. Named `pending_count` because `count` is reserved by Terraform.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *FollowRequestsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FollowRequestsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FollowRequestsDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_follow_requests data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accounts, newest, err := d.client.followRequests(ctx, data.MaxId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read follow requests",
+			fmt.Sprintf("Failed to read follow requests: %s", err),
+		)
+		return
+	}
+
+	data.setFollowRequests(accounts)
+	data.SnapshotMaxId = snapshotMaxIDValue(data.MaxId, newest)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_follow_requests data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setFollowRequests maps the requesting accounts onto the model.
+func (data *FollowRequestsDataSourceModel) setFollowRequests(accounts []*mastodon.Account) {
+	data.Accounts = make([]FollowRequestAccountModel, 0, len(accounts))
+	for _, account := range accounts {
+		data.Accounts = append(data.Accounts, FollowRequestAccountModel{
+			Id:          types.StringValue(string(account.ID)),
+			Acct:        types.StringValue(account.Acct),
+			DisplayName: types.StringValue(account.DisplayName),
+		})
+	}
+	data.PendingCount = types.Int64Value(int64(len(accounts)))
+}
+
+// followRequests returns every pending follow request older than maxID,
+// following the pagination links until the last page, along with the
+// pagination ID of the newest request. An empty maxID starts at the newest
+// request.
+func (c *MastodonClient) followRequests(ctx context.Context, maxID string) ([]*mastodon.Account, string, error) {
+	var accounts []*mastodon.Account
+	var newest string
+	pg := mastodon.Pagination{MaxID: mastodon.ID(maxID), Limit: followRequestsPageSize}
+	for {
+		requested := pg.MaxID
+		page, err := c.GetFollowRequests(ctx, &pg)
+		if err != nil {
+			return nil, "", err
+		}
+		accounts = append(accounts, page...)
+
+		// The accounts do not carry the pagination IDs of their requests,
+		// so the newest one is taken from the link to the previous page.
+		if newest == "" && len(page) > 0 {
+			newest = string(pg.MinID)
+			if newest == "" {
+				newest = string(pg.SinceID)
+			}
+		}
+
+		// The library leaves the pagination untouched when the response has
+		// no Link header, so an unchanged max_id means there are no more
+		// pages.
+		if len(page) == 0 || pg.MaxID == "" || pg.MaxID == requested {
+			return accounts, newest, nil
+		}
+		pg = mastodon.Pagination{MaxID: pg.MaxID, Limit: followRequestsPageSize}
+	}
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccFollowRequestsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccFollowRequestsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_follow_requests.test", "pending_count"),
+				),
+			},
+		},
+	})
+}
+
+const testAccFollowRequestsDataSourceConfig = `
+data "mastodon_follow_requests" "test" {}
+`
+
+func TestFollowRequests(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("max_id") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/follow_requests?max_id=102>; rel="next", <%s/api/v1/follow_requests?min_id=103>; rel="prev"`, server.URL, server.URL))
+			_, _ = w.Write([]byte(`[{"id":"1","acct":"alice","display_name":"Alice"},{"id":"2","acct":"bob@remote.example","display_name":"Bob"}]`))
+		case "102":
+			_, _ = w.Write([]byte(`[{"id":"3","acct":"carol","display_name":""}]`))
+		default:
+			t.Errorf("unexpected request for %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	accounts, newest, err := client.followRequests(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "103", newest)
+
+	var data FollowRequestsDataSourceModel
+	data.setFollowRequests(accounts)
+	assert.Equal(t, int64(3), data.PendingCount.ValueInt64())
+	assert.Equal(t, FollowRequestAccountModel{
+		Id:          types.StringValue("2"),
+		Acct:        types.StringValue("bob@remote.example"),
+		DisplayName: types.StringValue("Bob"),
+	}, data.Accounts[1])
+
+	// A max_id pins the read to older requests.
+	accounts, _, err = client.followRequests(context.Background(), "102")
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, mastodon.ID("3"), accounts[0].ID)
+}
+
+func TestFollowRequests_NonePending(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	accounts, newest, err := client.followRequests(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Empty(t, newest)
+
+	var data FollowRequestsDataSourceModel
+	data.setFollowRequests(accounts)
+	assert.Equal(t, int64(0), data.PendingCount.ValueInt64())
+	assert.NotNil(t, data.Accounts)
+	assert.Empty(t, data.Accounts)
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FollowResource{}
+var _ resource.ResourceWithImportState = &FollowResource{}
+
+func NewFollowResource() resource.Resource {
+	return &FollowResource{}
+}
+
+// FollowResource defines the resource implementation.
+type FollowResource struct {
+	client *MastodonClient
+}
+
+// FollowResourceModel describes the resource data model.
+type FollowResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	AccountId  types.String `tfsdk:"account_id"`
+	Acct       types.String `tfsdk:"acct"`
+	Reblogs    types.Bool   `tfsdk:"reblogs"`
+	Notify     types.Bool   `tfsdk:"notify"`
+	Following  types.Bool   `tfsdk:"following"`
+	FollowedBy types.Bool   `tfsdk:"followed_by"`
+	Requested  types.Bool   `tfsdk:"requested"`
+
+	AccessToken types.String `tfsdk:"access_token"`
+}
+
+func (r *FollowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_follow"
+}
+
+func (r *FollowResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource follows an account as the authenticated account, and unfollows it when destroyed. " +
+			"Following a locked account sends a follow request, which the account has to approve.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the follow, the same as `account_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the account to follow. Exactly one of `account_id` and `acct` must be set; when `acct` is set, this is the ID it resolved to.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("acct")),
+				},
+			},
+			"acct": schema.StringAttribute{
+				MarkdownDescription: "Handle of the account to follow, e.g. `tedivm@hachyderm.io`. The account must already be known to the configured server.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"reblogs": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account's boosts show up in the home timeline. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"notify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to be notified when the account posts. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"following": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account follows the account. `false` while a follow request is pending.",
+				Computed:            true,
+			},
+			"followed_by": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account follows the authenticated account.",
+				Computed:            true,
+			},
+			"requested": schema.BoolAttribute{
+				MarkdownDescription: "Whether a follow request to the account is waiting for approval.",
+				Computed:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token of another account on the same instance to follow as, instead of the provider's account. " +
+					"The provider's server and application credentials are still used. Changing it creates a new follow.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FollowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FollowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FollowResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	if !data.Acct.IsNull() {
+		account, err := client.AccountLookup(ctx, client.normalizeHandle(data.Acct.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("acct"),
+				"Unable to Find Account",
+				fmt.Sprintf("Unable to look up %s, got error: %s", data.Acct.ValueString(), err),
+			)
+			return
+		}
+		data.AccountId = types.StringValue(string(account.ID))
+	}
+
+	if client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping follow.")
+		data.Id = types.StringValue(validateOnlyID)
+		data.setRelationship(nil)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	rel, err := client.followAccount(ctx, data.AccountId.ValueString(), data.Reblogs.ValueBool(), data.Notify.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to follow account, got error: %s", err))
+		return
+	}
+
+	data.Id = data.AccountId
+	data.setRelationship(rel)
+
+	tflog.Trace(ctx, "followed an account")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FollowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FollowResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "follow was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	rel, err := client.getRelationship(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read relationship, got error: %s", err))
+		return
+	}
+
+	if rel == nil || (!rel.Following && !rel.Requested) {
+		// The account was unfollowed, or the follow request rejected,
+		// outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.AccountId = data.Id
+	data.Reblogs = types.BoolValue(rel.ShowingReblogs)
+	data.Notify = types.BoolValue(rel.Notifying)
+	data.setRelationship(rel)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FollowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FollowResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping follow update.")
+		data.setRelationship(nil)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	// Following an account again updates the options of the follow.
+	rel, err := client.followAccount(ctx, data.Id.ValueString(), data.Reblogs.ValueBool(), data.Notify.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update follow, got error: %s", err))
+		return
+	}
+
+	data.setRelationship(rel)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FollowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FollowResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping unfollow.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	// Unfollowing also withdraws a pending follow request.
+	_, err = client.AccountUnfollow(ctx, mastodon.ID(data.Id.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unfollow account, got error: %s", err))
+		return
+	}
+}
+
+func (r *FollowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), req.ID)...)
+}
+
+// setRelationship maps the relationship with the followed account onto the
+// model, setting the flags to false without one.
+func (data *FollowResourceModel) setRelationship(rel *relationship) {
+	if rel == nil {
+		data.Following = types.BoolValue(false)
+		data.FollowedBy = types.BoolValue(false)
+		data.Requested = types.BoolValue(false)
+		return
+	}
+
+	data.Following = types.BoolValue(rel.Following)
+	data.FollowedBy = types.BoolValue(rel.FollowedBy)
+	data.Requested = types.BoolValue(rel.Requested)
+}
+
+// followAccount follows an account, or updates the options of an existing
+// follow. The mastodon library cannot set the options, so the endpoint is
+// called directly.
+func (c *MastodonClient) followAccount(ctx context.Context, id string, reblogs bool, notify bool) (*relationship, error) {
+	params := url.Values{}
+	params.Set("reblogs", strconv.FormatBool(reblogs))
+	params.Set("notify", strconv.FormatBool(notify))
+
+	var rel relationship
+	if err := c.doAPI(ctx, http.MethodPost, "/api/v1/accounts/"+url.PathEscape(id)+"/follow", params, &rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// getRelationship reads the authenticated account's relationship with an
+// account, returning nil when the server reports none.
+func (c *MastodonClient) getRelationship(ctx context.Context, id string) (*relationship, error) {
+	// The mastodon library drops `note`, `notifying` and `languages`, so the
+	// endpoint is called directly.
+	var relationships []relationship
+	params := url.Values{"id[]": {id}}
+	if err := c.doAPI(ctx, http.MethodGet, "/api/v1/accounts/relationships", params, &relationships); err != nil {
+		return nil, err
+	}
+	if len(relationships) == 0 {
+		return nil, nil
+	}
+	return &relationships[0], nil
+}
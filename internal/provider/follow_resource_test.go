@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccFollowResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mastodon_follow" "test" {
+  acct = "tedivm@hachyderm.io"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mastodon_follow.test", "account_id"),
+					resource.TestCheckResourceAttrPair("mastodon_follow.test", "id", "mastodon_follow.test", "account_id"),
+					resource.TestCheckResourceAttr("mastodon_follow.test", "reblogs", "true"),
+					resource.TestCheckResourceAttr("mastodon_follow.test", "notify", "false"),
+				),
+			},
+			// Change the options of the follow in place.
+			{
+				Config: `
+resource "mastodon_follow" "test" {
+  acct    = "tedivm@hachyderm.io"
+  reblogs = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_follow.test", "reblogs", "false"),
+				),
+			},
+			{
+				ResourceName:            "mastodon_follow.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"acct"},
+			},
+		},
+	})
+}
+
+func TestFollowAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/accounts/7/follow" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "false", r.PostForm.Get("reblogs"))
+		assert.Equal(t, "true", r.PostForm.Get("notify"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"7","following":false,"requested":true,"showing_reblogs":false,"notifying":true}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	rel, err := client.followAccount(context.Background(), "7", false, true)
+	assert.NoError(t, err)
+
+	// A locked account leaves the follow requested.
+	var data FollowResourceModel
+	data.setRelationship(rel)
+	assert.False(t, data.Following.ValueBool())
+	assert.True(t, data.Requested.ValueBool())
+	assert.True(t, rel.Notifying)
+}
+
+func TestGetRelationship(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("id[]") == "7" {
+			_, _ = w.Write([]byte(`[{"id":"7","following":true,"followed_by":true,"showing_reblogs":true}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	rel, err := client.getRelationship(context.Background(), "7")
+	assert.NoError(t, err)
+	assert.True(t, rel.Following)
+	assert.True(t, rel.FollowedBy)
+
+	rel, err = client.getRelationship(context.Background(), "8")
+	assert.NoError(t, err)
+	assert.Nil(t, rel)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringValueOrNull maps an empty string returned by the API to a null value
+// so optional fields are not stored as empty strings.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// timeValueOrNull maps a timestamp returned by the API to an RFC 3339 string,
+// or to a null value when the API left it out.
+func timeValueOrNull(value time.Time) types.String {
+	if value.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(value.UTC().Format(time.RFC3339))
+}
+
+// int64ValueOrNull maps a zero limit, which the API leaves out when it does
+// not advertise it, to a null value.
+func int64ValueOrNull(value int) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(value))
+}
+
+// boolValueOrFalse maps the optional boolean flags the API returns on
+// statuses, which are absent for unauthenticated requests, to a bool.
+func boolValueOrFalse(value interface{}) types.Bool {
+	b, ok := value.(bool)
+	return types.BoolValue(ok && b)
+}
+
+// idValueOrNull maps an optional ID returned by the API, which is either a
+// string or absent, to a string value.
+func idValueOrNull(value interface{}) types.String {
+	id, ok := value.(string)
+	if !ok {
+		return types.StringNull()
+	}
+	return stringValueOrNull(id)
+}
+
+// snapshotMaxIDValue returns the max_id that pins a paginated read to the
+// items it returned: the configured max_id when there is one, or else an ID
+// just above the newest item, null when there are no items.
+func snapshotMaxIDValue(maxID types.String, newest string) types.String {
+	if !maxID.IsNull() {
+		return maxID
+	}
+	if newest == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(nextID(newest))
+}
+
+// nextID returns the ID following a numeric ID, as Mastodon uses, so a read
+// with it as the exclusive max_id still includes the item. Servers with other
+// IDs, such as Pleroma, get the ID itself, which leaves that item out.
+func nextID(id string) string {
+	digits := []byte(id)
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return id
+		}
+	}
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return string(digits)
+		}
+		digits[i] = '0'
+	}
+	return "1" + string(digits)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// importBlockWriter appends a Terraform `import` block for every post that is
+// preserved on destroy, so it can be adopted again by a later configuration.
+type importBlockWriter struct {
+	file *fileAppender
+}
+
+func newImportBlockWriter(path string) *importBlockWriter {
+	return &importBlockWriter{file: appenderFor(path)}
+}
+
+// importBlock renders the import block of a resource. Terraform does not tell
+// providers the address of the resource being destroyed, so the resource name
+// is derived from its ID and may need renaming before use.
+func importBlock(resourceType string, id string, preservedAt time.Time) string {
+	return fmt.Sprintf(`# %[1]s %[2]s was preserved on destroy at %[3]s.
+import {
+  to = %[1]s.%[4]s
+  id = %[5]s
+}
+
+`, resourceType, id, preservedAt.UTC().Format(time.RFC3339), importResourceName(id), strconv.Quote(id))
+}
+
+// importResourceName turns an ID into a valid resource name.
+func importResourceName(id string) string {
+	name := []rune("preserved_")
+	for _, r := range id {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			name = append(name, r)
+		} else {
+			name = append(name, '_')
+		}
+	}
+	return string(name)
+}
+
+// write appends the import block of a resource.
+func (w *importBlockWriter) write(resourceType string, id string) error {
+	return w.file.append([]byte(importBlock(resourceType, id, time.Now())))
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportBlock(t *testing.T) {
+	block := importBlock("mastodon_post", "109372843234", time.Date(2024, 5, 6, 12, 30, 0, 0, time.UTC))
+	assert.Equal(t, `# mastodon_post 109372843234 was preserved on destroy at 2024-05-06T12:30:00Z.
+import {
+  to = mastodon_post.preserved_109372843234
+  id = "109372843234"
+}
+
+`, block)
+}
+
+func TestImportBlockWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imports.tf")
+	w := newImportBlockWriter(path)
+
+	assert.NoError(t, w.write("mastodon_post", "109372843234"))
+	assert.NoError(t, w.write("mastodon_post", "109372843235"))
+
+	src, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	file, diags := hclsyntax.ParseConfig(src, "imports.tf", hcl.InitialPos)
+	assert.False(t, diags.HasErrors(), diags.Error())
+
+	blocks := file.Body.(*hclsyntax.Body).Blocks
+	if assert.Len(t, blocks, 2) {
+		assert.Equal(t, "import", blocks[0].Type)
+		assert.Contains(t, blocks[0].Body.Attributes, "to")
+		assert.Contains(t, blocks[0].Body.Attributes, "id")
+		value, diags := blocks[1].Body.Attributes["id"].Expr.Value(nil)
+		assert.False(t, diags.HasErrors())
+		assert.Equal(t, "109372843235", value.AsString())
+	}
+}
@@ -0,0 +1,559 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &InstanceDataSource{}
+
+func NewInstanceDataSource() datasource.DataSource {
+	return &InstanceDataSource{}
+}
+
+// InstanceDataSource defines the data source implementation.
+type InstanceDataSource struct {
+	client *MastodonClient
+}
+
+// InstanceDataSourceModel describes the data source data model.
+type InstanceDataSourceModel struct {
+	Uri              types.String `tfsdk:"uri"`
+	Title            types.String `tfsdk:"title"`
+	Description      types.String `tfsdk:"description"`
+	Version          types.String `tfsdk:"version"`
+	Email            types.String `tfsdk:"email"`
+	MaxTootChars     types.Int64  `tfsdk:"max_toot_chars"`
+	ContactAccount   types.Object `tfsdk:"contact_account"`
+	Registrations    types.Bool   `tfsdk:"registrations"`
+	ApprovalRequired types.Bool   `tfsdk:"approval_required"`
+	Thumbnail        types.String `tfsdk:"thumbnail"`
+	Banner           types.String `tfsdk:"banner"`
+	ContentTypes     types.List   `tfsdk:"content_types"`
+	Configuration    types.Object `tfsdk:"configuration"`
+}
+
+// instance mirrors the instance entity, including the fields the mastodon
+// library does not decode.
+type instance struct {
+	mastodon.Instance
+	Registrations    bool  `json:"registrations"`
+	ApprovalRequired *bool `json:"approval_required"`
+
+	Thumbnail instanceImage `json:"thumbnail"`
+
+	// BackgroundImage is the banner of Pleroma and Akkoma instances.
+	BackgroundImage string `json:"background_image"`
+
+	// MaxTootChars is the post length limit of Pleroma and Akkoma
+	// instances.
+	MaxTootChars int `json:"max_toot_chars"`
+
+	// Configuration replaces the library's, which fails to decode the
+	// lists some forks add to it.
+	Configuration instanceConfiguration `json:"configuration"`
+
+	// PollLimits and UploadLimit are the limits of Pleroma and Akkoma
+	// instances, which do not have a configuration.
+	PollLimits struct {
+		MaxOptions     int `json:"max_options"`
+		MaxOptionChars int `json:"max_option_chars"`
+		MinExpiration  int `json:"min_expiration"`
+		MaxExpiration  int `json:"max_expiration"`
+	} `json:"poll_limits"`
+	UploadLimit int `json:"upload_limit"`
+
+	Pleroma struct {
+		Metadata struct {
+			PostFormats []string `json:"post_formats"`
+		} `json:"metadata"`
+	} `json:"pleroma"`
+}
+
+// instanceConfiguration holds the limits the instance advertises to clients.
+type instanceConfiguration struct {
+	Statuses struct {
+		MaxCharacters            int `json:"max_characters"`
+		MaxMediaAttachments      int `json:"max_media_attachments"`
+		CharactersReservedPerURL int `json:"characters_reserved_per_url"`
+
+		// SupportedMimeTypes lists the post content types glitch-soc
+		// accepts.
+		SupportedMimeTypes []string `json:"supported_mime_types"`
+	} `json:"statuses"`
+
+	MediaAttachments instanceMediaAttachments `json:"media_attachments"`
+
+	Polls instancePolls `json:"polls"`
+}
+
+// instanceMediaAttachments holds the limits of uploaded media.
+type instanceMediaAttachments struct {
+	SupportedMimeTypes  []string `json:"supported_mime_types"`
+	ImageSizeLimit      int      `json:"image_size_limit"`
+	ImageMatrixLimit    int      `json:"image_matrix_limit"`
+	VideoSizeLimit      int      `json:"video_size_limit"`
+	VideoFrameRateLimit int      `json:"video_frame_rate_limit"`
+	VideoMatrixLimit    int      `json:"video_matrix_limit"`
+}
+
+// instancePolls holds the limits of polls.
+type instancePolls struct {
+	MaxOptions             int `json:"max_options"`
+	MaxCharactersPerOption int `json:"max_characters_per_option"`
+	MinExpiration          int `json:"min_expiration"`
+	MaxExpiration          int `json:"max_expiration"`
+}
+
+// maxCharacters returns the post length limit, falling back to Mastodon's
+// default.
+func (inst *instance) maxCharacters() int {
+	switch {
+	case inst.Configuration.Statuses.MaxCharacters > 0:
+		return inst.Configuration.Statuses.MaxCharacters
+	case inst.MaxTootChars > 0:
+		return inst.MaxTootChars
+	}
+	return defaultMaxPostCharacters
+}
+
+// charactersReservedPerURL returns the length links count as, falling back
+// to Mastodon's default.
+func (inst *instance) charactersReservedPerURL() int {
+	if reserved := inst.Configuration.Statuses.CharactersReservedPerURL; reserved > 0 {
+		return reserved
+	}
+	return defaultCharactersReservedPerURL
+}
+
+// maxMediaAttachments returns the number of media attachments allowed on a
+// post, falling back to Mastodon's default.
+func (inst *instance) maxMediaAttachments() int {
+	if limit := inst.Configuration.Statuses.MaxMediaAttachments; limit > 0 {
+		return limit
+	}
+	return defaultMaxMediaAttachments
+}
+
+// mediaAttachments returns the media limits from the configuration, falling
+// back to the upload limit of Pleroma and Akkoma instances for the sizes.
+func (inst *instance) mediaAttachments() instanceMediaAttachments {
+	media := inst.Configuration.MediaAttachments
+	if media.ImageSizeLimit == 0 && media.VideoSizeLimit == 0 {
+		media.ImageSizeLimit = inst.UploadLimit
+		media.VideoSizeLimit = inst.UploadLimit
+	}
+	return media
+}
+
+// polls returns the poll limits from the configuration, or from the poll
+// limits of Pleroma and Akkoma instances.
+func (inst *instance) polls() instancePolls {
+	if inst.Configuration.Polls.MaxOptions > 0 {
+		return inst.Configuration.Polls
+	}
+	return instancePolls{
+		MaxOptions:             inst.PollLimits.MaxOptions,
+		MaxCharactersPerOption: inst.PollLimits.MaxOptionChars,
+		MinExpiration:          inst.PollLimits.MinExpiration,
+		MaxExpiration:          inst.PollLimits.MaxExpiration,
+	}
+}
+
+// instanceImage is an image URL, which the v1 instance endpoint reports as a
+// plain string and the v2 endpoint as an object with a `url` field.
+type instanceImage struct {
+	URL string
+}
+
+func (i *instanceImage) UnmarshalJSON(b []byte) error {
+	var url string
+	if err := json.Unmarshal(b, &url); err == nil {
+		i.URL = url
+		return nil
+	}
+
+	var image struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(b, &image); err != nil {
+		return err
+	}
+	i.URL = image.URL
+	return nil
+}
+
+// instanceStatusesAttrTypes describes the `configuration.statuses` object.
+var instanceStatusesAttrTypes = map[string]attr.Type{
+	"max_characters":              types.Int64Type,
+	"max_media_attachments":       types.Int64Type,
+	"characters_reserved_per_url": types.Int64Type,
+}
+
+// instancePollsAttrTypes describes the `configuration.polls` object.
+var instancePollsAttrTypes = map[string]attr.Type{
+	"max_options":               types.Int64Type,
+	"max_characters_per_option": types.Int64Type,
+	"min_expiration":            types.Int64Type,
+	"max_expiration":            types.Int64Type,
+}
+
+// instanceMediaAttachmentsAttrTypes describes the
+// `configuration.media_attachments` object.
+var instanceMediaAttachmentsAttrTypes = map[string]attr.Type{
+	"supported_mime_types":   types.ListType{ElemType: types.StringType},
+	"image_size_limit":       types.Int64Type,
+	"image_matrix_limit":     types.Int64Type,
+	"video_size_limit":       types.Int64Type,
+	"video_frame_rate_limit": types.Int64Type,
+	"video_matrix_limit":     types.Int64Type,
+}
+
+// instanceConfigurationAttrTypes describes the `configuration` object.
+var instanceConfigurationAttrTypes = map[string]attr.Type{
+	"statuses":          types.ObjectType{AttrTypes: instanceStatusesAttrTypes},
+	"polls":             types.ObjectType{AttrTypes: instancePollsAttrTypes},
+	"media_attachments": types.ObjectType{AttrTypes: instanceMediaAttachmentsAttrTypes},
+}
+
+// instanceContactAccountAttrTypes describes the `contact_account` object.
+var instanceContactAccountAttrTypes = map[string]attr.Type{
+	"acct":         types.StringType,
+	"display_name": types.StringType,
+	"url":          types.StringType,
+}
+
+func (d *InstanceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance"
+}
+
+func (d *InstanceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads metadata about the Mastodon instance the provider is connected to.",
+
+		Attributes: map[string]schema.Attribute{
+			"uri": schema.StringAttribute{
+				MarkdownDescription: "The domain name of the instance.",
+				Computed:            true,
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The name of the instance.",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the instance. Null when the instance has none.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The version of the server software, e.g. `4.2.8`. Forks report a Mastodon compatible version followed by their own, e.g. `2.7.2 (compatible; Akkoma 3.10.0)`.",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address to contact the instance's staff. Null when the instance does not advertise one.",
+				Computed:            true,
+			},
+			"max_toot_chars": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of characters in a post, the same as `configuration.statuses.max_characters`. " +
+					"Useful to keep `mastodon_post` content within the instance's limit instead of assuming Mastodon's default of 500.",
+				Computed: true,
+			},
+			"contact_account": schema.SingleNestedAttribute{
+				MarkdownDescription: "The account designated as the instance's contact. Null when the instance does not advertise one.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"acct": schema.StringAttribute{
+						MarkdownDescription: "The handle of the contact account.",
+						Computed:            true,
+					},
+					"display_name": schema.StringAttribute{
+						MarkdownDescription: "The display name of the contact account.",
+						Computed:            true,
+					},
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The profile URL of the contact account.",
+						Computed:            true,
+					},
+				},
+			},
+			"registrations": schema.BoolAttribute{
+				MarkdownDescription: "Whether the instance accepts new account registrations.",
+				Computed:            true,
+			},
+			"approval_required": schema.BoolAttribute{
+				MarkdownDescription: "Whether new registrations must be approved by a moderator. Defaults to `true` when the instance does not say, so configurations never assume signups are open.",
+				Computed:            true,
+			},
+			"thumbnail": schema.StringAttribute{
+				MarkdownDescription: "URL of the instance's thumbnail image. Null when the instance has none.",
+				Computed:            true,
+			},
+			"banner": schema.StringAttribute{
+				MarkdownDescription: "URL of the instance's banner image. Only Pleroma and Akkoma instances have one; null otherwise.",
+				Computed:            true,
+			},
+			"content_types": schema.ListAttribute{
+				MarkdownDescription: "The content types posts can be written in, e.g. `text/markdown`. Vanilla Mastodon only accepts `text/plain`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"configuration": schema.SingleNestedAttribute{
+				MarkdownDescription: "The limits the instance advertises to clients, mirroring the instance's `configuration`. " +
+					"Pleroma and Akkoma limits are read from their own fields. Limits the instance does not advertise are null.",
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"statuses": schema.SingleNestedAttribute{
+						MarkdownDescription: "Limits on posts.",
+						Computed:            true,
+						Attributes: map[string]schema.Attribute{
+							"max_characters": schema.Int64Attribute{
+								MarkdownDescription: "The maximum length of a post. Defaults to Mastodon's `500` when not advertised.",
+								Computed:            true,
+							},
+							"max_media_attachments": schema.Int64Attribute{
+								MarkdownDescription: "The maximum number of media attachments on a post. Defaults to Mastodon's `4` when not advertised.",
+								Computed:            true,
+							},
+							"characters_reserved_per_url": schema.Int64Attribute{
+								MarkdownDescription: "The length every link counts as, whatever its actual length. Defaults to Mastodon's `23` when not advertised.",
+								Computed:            true,
+							},
+						},
+					},
+					"polls": schema.SingleNestedAttribute{
+						MarkdownDescription: "Limits on polls.",
+						Computed:            true,
+						Attributes: map[string]schema.Attribute{
+							"max_options": schema.Int64Attribute{
+								MarkdownDescription: "The maximum number of options in a poll.",
+								Computed:            true,
+							},
+							"max_characters_per_option": schema.Int64Attribute{
+								MarkdownDescription: "The maximum length of a poll option.",
+								Computed:            true,
+							},
+							"min_expiration": schema.Int64Attribute{
+								MarkdownDescription: "The shortest duration of a poll, in seconds.",
+								Computed:            true,
+							},
+							"max_expiration": schema.Int64Attribute{
+								MarkdownDescription: "The longest duration of a poll, in seconds.",
+								Computed:            true,
+							},
+						},
+					},
+					"media_attachments": schema.SingleNestedAttribute{
+						MarkdownDescription: "Limits on uploaded media.",
+						Computed:            true,
+						Attributes: map[string]schema.Attribute{
+							"supported_mime_types": schema.ListAttribute{
+								MarkdownDescription: "The MIME types that can be uploaded.",
+								ElementType:         types.StringType,
+								Computed:            true,
+							},
+							"image_size_limit": schema.Int64Attribute{
+								MarkdownDescription: "The maximum size of an image, in bytes.",
+								Computed:            true,
+							},
+							"image_matrix_limit": schema.Int64Attribute{
+								MarkdownDescription: "The maximum number of pixels of an image.",
+								Computed:            true,
+							},
+							"video_size_limit": schema.Int64Attribute{
+								MarkdownDescription: "The maximum size of a video, in bytes.",
+								Computed:            true,
+							},
+							"video_frame_rate_limit": schema.Int64Attribute{
+								MarkdownDescription: "The maximum frame rate of a video.",
+								Computed:            true,
+							},
+							"video_matrix_limit": schema.Int64Attribute{
+								MarkdownDescription: "The maximum number of pixels of a video frame.",
+								Computed:            true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *InstanceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *InstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data InstanceDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_instance data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The mastodon library drops the registration settings, so the endpoint
+	// is called directly.
+	var inst instance
+	err := d.client.doAPI(ctx, http.MethodGet, "/api/v1/instance", nil, &inst)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read instance",
+			fmt.Sprintf("Failed to read instance: %s", err),
+		)
+		return
+	}
+
+	// Mastodon describes the thumbnail and configuration in more detail on
+	// the v2 endpoint, which forks do not implement.
+	if detectSoftware(inst.Version) == softwareMastodon {
+		var v2 struct {
+			Thumbnail     instanceImage         `json:"thumbnail"`
+			Configuration instanceConfiguration `json:"configuration"`
+		}
+		err := d.client.doAPI(ctx, http.MethodGet, "/api/v2/instance", nil, &v2)
+		if err != nil {
+			tflog.Debug(ctx, "falling back to the v1 instance thumbnail and configuration", map[string]interface{}{"error": err.Error()})
+		} else {
+			if v2.Thumbnail.URL != "" {
+				inst.Thumbnail = v2.Thumbnail
+			}
+			if v2.Configuration.Statuses.MaxCharacters > 0 {
+				inst.Configuration = v2.Configuration
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(data.setInstance(&inst)...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_instance data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setInstance maps an instance entity onto the model.
+func (data *InstanceDataSourceModel) setInstance(instance *instance) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Uri = types.StringValue(instance.URI)
+	data.Title = types.StringValue(instance.Title)
+	data.Description = stringValueOrNull(instance.Description)
+	data.Version = types.StringValue(instance.Version)
+	data.Email = stringValueOrNull(instance.EMail)
+	data.MaxTootChars = types.Int64Value(int64(instance.maxCharacters()))
+	data.Registrations = types.BoolValue(instance.Registrations)
+	data.Thumbnail = stringValueOrNull(instance.Thumbnail.URL)
+	data.Banner = stringValueOrNull(instance.BackgroundImage)
+
+	contentTypes := []attr.Value{}
+	for _, contentType := range supportedContentTypes(instance) {
+		contentTypes = append(contentTypes, types.StringValue(contentType))
+	}
+	list, d := types.ListValue(types.StringType, contentTypes)
+	diags.Append(d...)
+	data.ContentTypes = list
+
+	configuration, d := instanceConfigurationValue(instance)
+	diags.Append(d...)
+	data.Configuration = configuration
+
+	// Assume manual approval when the instance does not say.
+	approvalRequired := true
+	if instance.ApprovalRequired != nil {
+		approvalRequired = *instance.ApprovalRequired
+	}
+	data.ApprovalRequired = types.BoolValue(approvalRequired)
+
+	if instance.ContactAccount == nil || instance.ContactAccount.Acct == "" {
+		data.ContactAccount = types.ObjectNull(instanceContactAccountAttrTypes)
+	} else {
+		contact, d := types.ObjectValue(instanceContactAccountAttrTypes, map[string]attr.Value{
+			"acct":         types.StringValue(instance.ContactAccount.Acct),
+			"display_name": types.StringValue(instance.ContactAccount.DisplayName),
+			"url":          types.StringValue(instance.ContactAccount.URL),
+		})
+		diags.Append(d...)
+		data.ContactAccount = contact
+	}
+
+	return diags
+}
+
+// instanceConfigurationValue builds the `configuration` object, reading the
+// limits of Pleroma and Akkoma instances from their own fields.
+func instanceConfigurationValue(instance *instance) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	statuses, d := types.ObjectValue(instanceStatusesAttrTypes, map[string]attr.Value{
+		"max_characters":              types.Int64Value(int64(instance.maxCharacters())),
+		"max_media_attachments":       types.Int64Value(int64(instance.maxMediaAttachments())),
+		"characters_reserved_per_url": types.Int64Value(int64(instance.charactersReservedPerURL())),
+	})
+	diags.Append(d...)
+
+	polls := instance.polls()
+	pollsValue, d := types.ObjectValue(instancePollsAttrTypes, map[string]attr.Value{
+		"max_options":               int64ValueOrNull(polls.MaxOptions),
+		"max_characters_per_option": int64ValueOrNull(polls.MaxCharactersPerOption),
+		"min_expiration":            int64ValueOrNull(polls.MinExpiration),
+		"max_expiration":            int64ValueOrNull(polls.MaxExpiration),
+	})
+	diags.Append(d...)
+
+	media := instance.mediaAttachments()
+	mimeTypes := types.ListNull(types.StringType)
+	if media.SupportedMimeTypes != nil {
+		mimeTypes, d = types.ListValueFrom(context.Background(), types.StringType, media.SupportedMimeTypes)
+		diags.Append(d...)
+	}
+	mediaValue, d := types.ObjectValue(instanceMediaAttachmentsAttrTypes, map[string]attr.Value{
+		"supported_mime_types":   mimeTypes,
+		"image_size_limit":       int64ValueOrNull(media.ImageSizeLimit),
+		"image_matrix_limit":     int64ValueOrNull(media.ImageMatrixLimit),
+		"video_size_limit":       int64ValueOrNull(media.VideoSizeLimit),
+		"video_frame_rate_limit": int64ValueOrNull(media.VideoFrameRateLimit),
+		"video_matrix_limit":     int64ValueOrNull(media.VideoMatrixLimit),
+	})
+	diags.Append(d...)
+
+	configuration, d := types.ObjectValue(instanceConfigurationAttrTypes, map[string]attr.Value{
+		"statuses":          statuses,
+		"polls":             pollsValue,
+		"media_attachments": mediaValue,
+	})
+	diags.Append(d...)
+	return configuration, diags
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccInstanceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccInstanceDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_instance.test", "uri"),
+					resource.TestCheckResourceAttrSet("data.mastodon_instance.test", "title"),
+					resource.TestCheckResourceAttrSet("data.mastodon_instance.test", "version"),
+					resource.TestCheckResourceAttrSet("data.mastodon_instance.test", "max_toot_chars"),
+					resource.TestCheckResourceAttrSet("data.mastodon_instance.test", "registrations"),
+					resource.TestCheckResourceAttrSet("data.mastodon_instance.test", "approval_required"),
+				),
+			},
+		},
+	})
+}
+
+const testAccInstanceDataSourceConfig = `
+data "mastodon_instance" "test" {}
+`
+
+func TestInstanceDataSourceModel_Registrations(t *testing.T) {
+	var inst instance
+	err := json.Unmarshal([]byte(`{"uri":"mastodon.example","registrations":true,"approval_required":false}`), &inst)
+	assert.NoError(t, err)
+
+	var data InstanceDataSourceModel
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t, "mastodon.example", data.Uri.ValueString())
+	assert.True(t, data.Registrations.ValueBool())
+	assert.False(t, data.ApprovalRequired.ValueBool())
+	assert.True(t, data.ContactAccount.IsNull())
+
+	assert.True(t, data.Thumbnail.IsNull())
+	assert.True(t, data.Banner.IsNull())
+
+	// Older servers do not report approval_required.
+	inst = instance{}
+	err = json.Unmarshal([]byte(`{"uri":"mastodon.example","registrations":true}`), &inst)
+	assert.NoError(t, err)
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.True(t, data.ApprovalRequired.ValueBool())
+}
+
+func TestInstanceDataSourceModel_Metadata(t *testing.T) {
+	var inst instance
+	err := json.Unmarshal([]byte(`{"uri":"mastodon.example","title":"Mastodon Example","description":"A place to test.","email":"admin@mastodon.example","version":"4.2.8"}`), &inst)
+	assert.NoError(t, err)
+
+	var data InstanceDataSourceModel
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t, "Mastodon Example", data.Title.ValueString())
+	assert.Equal(t, "A place to test.", data.Description.ValueString())
+	assert.Equal(t, "4.2.8", data.Version.ValueString())
+	assert.Equal(t, "admin@mastodon.example", data.Email.ValueString())
+	assert.Equal(t, int64(defaultMaxPostCharacters), data.MaxTootChars.ValueInt64())
+
+	// Pleroma and Akkoma report their post length limit on their own.
+	inst = instance{}
+	err = json.Unmarshal([]byte(`{"uri":"akkoma.example","title":"Akkoma Example","version":"2.7.2 (compatible; Akkoma 3.10.0)","max_toot_chars":5000}`), &inst)
+	assert.NoError(t, err)
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.True(t, data.Description.IsNull())
+	assert.True(t, data.Email.IsNull())
+	assert.Equal(t, int64(5000), data.MaxTootChars.ValueInt64())
+}
+
+func TestInstanceDataSourceModel_Images(t *testing.T) {
+	// Pleroma and Akkoma only implement v1, where the thumbnail is a string.
+	var inst instance
+	err := json.Unmarshal([]byte(`{"uri":"akkoma.example","version":"2.7.2 (compatible; Akkoma 3.10.0)","thumbnail":"https://akkoma.example/instance/thumbnail.jpeg","background_image":"https://akkoma.example/images/city.jpg"}`), &inst)
+	assert.NoError(t, err)
+
+	var data InstanceDataSourceModel
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t, "https://akkoma.example/instance/thumbnail.jpeg", data.Thumbnail.ValueString())
+	assert.Equal(t, "https://akkoma.example/images/city.jpg", data.Banner.ValueString())
+
+	// Mastodon's v2 endpoint reports the thumbnail as an object.
+	inst = instance{}
+	err = json.Unmarshal([]byte(`{"uri":"mastodon.example","version":"4.2.8","thumbnail":{"url":"https://files.mastodon.example/site_uploads/files/000/000/001/@1x/thumbnail.png","blurhash":"UeKUpFxuo~R%0nW;WCnhF6RjaJt757oJodS$"}}`), &inst)
+	assert.NoError(t, err)
+
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t, "https://files.mastodon.example/site_uploads/files/000/000/001/@1x/thumbnail.png", data.Thumbnail.ValueString())
+	assert.True(t, data.Banner.IsNull())
+}
+
+func TestInstanceDataSourceModel_ContentTypes(t *testing.T) {
+	var inst instance
+	err := json.Unmarshal([]byte(`{"uri":"akkoma.example","version":"2.7.2 (compatible; Akkoma 3.10.0)","pleroma":{"metadata":{"post_formats":["text/plain","text/html","text/markdown","text/bbcode"]}}}`), &inst)
+	assert.NoError(t, err)
+
+	var data InstanceDataSourceModel
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t, `["text/plain","text/html","text/markdown","text/bbcode"]`, data.ContentTypes.String())
+
+	// glitch-soc lists them in the instance configuration, next to limits the
+	// mastodon library decodes as numbers.
+	inst = instance{}
+	err = json.Unmarshal([]byte(`{"uri":"glitch.example","version":"4.2.8+glitch","configuration":{"statuses":{"max_characters":500,"supported_mime_types":["text/plain","text/markdown","text/html"]}}}`), &inst)
+	assert.NoError(t, err)
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t, `["text/plain","text/markdown","text/html"]`, data.ContentTypes.String())
+
+	inst = instance{}
+	err = json.Unmarshal([]byte(`{"uri":"mastodon.example","version":"4.2.8"}`), &inst)
+	assert.NoError(t, err)
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t, `["text/plain"]`, data.ContentTypes.String())
+}
+
+func TestInstanceDataSourceModel_Configuration(t *testing.T) {
+	var inst instance
+	err := json.Unmarshal([]byte(`{
+		"uri": "mastodon.example",
+		"version": "4.2.8",
+		"configuration": {
+			"statuses": {"max_characters": 1000, "max_media_attachments": 4, "characters_reserved_per_url": 23},
+			"media_attachments": {
+				"supported_mime_types": ["image/jpeg", "image/png", "video/mp4"],
+				"image_size_limit": 16777216,
+				"image_matrix_limit": 33177600,
+				"video_size_limit": 103809024,
+				"video_frame_rate_limit": 120,
+				"video_matrix_limit": 8294400
+			},
+			"polls": {"max_options": 4, "max_characters_per_option": 50, "min_expiration": 300, "max_expiration": 2629746}
+		}
+	}`), &inst)
+	assert.NoError(t, err)
+
+	var data InstanceDataSourceModel
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t,
+		`{"media_attachments":{"image_matrix_limit":33177600,"image_size_limit":16777216,"supported_mime_types":["image/jpeg","image/png","video/mp4"],"video_frame_rate_limit":120,"video_matrix_limit":8294400,"video_size_limit":103809024},`+
+			`"polls":{"max_characters_per_option":50,"max_expiration":2629746,"max_options":4,"min_expiration":300},`+
+			`"statuses":{"characters_reserved_per_url":23,"max_characters":1000,"max_media_attachments":4}}`,
+		data.Configuration.String())
+
+	// Akkoma reports its limits outside of a configuration.
+	inst = instance{}
+	err = json.Unmarshal([]byte(`{"uri":"akkoma.example","version":"2.7.2 (compatible; Akkoma 3.10.0)","max_toot_chars":5000,"upload_limit":16000000,"poll_limits":{"max_options":20,"max_option_chars":200,"min_expiration":0,"max_expiration":31536000}}`), &inst)
+	assert.NoError(t, err)
+	assert.False(t, data.setInstance(&inst).HasError())
+	assert.Equal(t,
+		`{"media_attachments":{"image_matrix_limit":<null>,"image_size_limit":16000000,"supported_mime_types":<null>,"video_frame_rate_limit":<null>,"video_matrix_limit":<null>,"video_size_limit":16000000},`+
+			`"polls":{"max_characters_per_option":200,"max_expiration":31536000,"max_options":20,"min_expiration":<null>},`+
+			`"statuses":{"characters_reserved_per_url":23,"max_characters":5000,"max_media_attachments":4}}`,
+		data.Configuration.String())
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// languageCodePattern matches a plausible post language: a lowercase ISO 639-1
+// code, or one of the few ISO 639-3 codes Mastodon accepts, optionally
+// followed by a region as in `zh-TW`.
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// Writing systems used by the language mismatch heuristic.
+const (
+	scriptLatin      = "Latin"
+	scriptCyrillic   = "Cyrillic"
+	scriptGreek      = "Greek"
+	scriptArabic     = "Arabic"
+	scriptHebrew     = "Hebrew"
+	scriptHan        = "Han"
+	scriptKana       = "Kana"
+	scriptHangul     = "Hangul"
+	scriptThai       = "Thai"
+	scriptDevanagari = "Devanagari"
+)
+
+// scriptTables maps each script to the unicode ranges that identify it.
+var scriptTables = map[string][]*unicode.RangeTable{
+	scriptLatin:      {unicode.Latin},
+	scriptCyrillic:   {unicode.Cyrillic},
+	scriptGreek:      {unicode.Greek},
+	scriptArabic:     {unicode.Arabic},
+	scriptHebrew:     {unicode.Hebrew},
+	scriptHan:        {unicode.Han},
+	scriptKana:       {unicode.Hiragana, unicode.Katakana},
+	scriptHangul:     {unicode.Hangul},
+	scriptThai:       {unicode.Thai},
+	scriptDevanagari: {unicode.Devanagari},
+}
+
+// languageScripts lists the scripts a post in a given ISO 639-1 language is
+// expected to be written in. Languages missing from this map are never
+// flagged.
+var languageScripts = map[string][]string{
+	"ja": {scriptHan, scriptKana},
+	"zh": {scriptHan},
+	"ko": {scriptHangul, scriptHan},
+	"ru": {scriptCyrillic},
+	"uk": {scriptCyrillic},
+	"bg": {scriptCyrillic},
+	"be": {scriptCyrillic},
+	"mk": {scriptCyrillic},
+	"kk": {scriptCyrillic},
+	"sr": {scriptCyrillic, scriptLatin},
+	"el": {scriptGreek},
+	"ar": {scriptArabic},
+	"fa": {scriptArabic},
+	"ur": {scriptArabic},
+	"he": {scriptHebrew},
+	"yi": {scriptHebrew},
+	"th": {scriptThai},
+	"hi": {scriptDevanagari},
+	"mr": {scriptDevanagari},
+	"ne": {scriptDevanagari},
+	"en": {scriptLatin},
+	"de": {scriptLatin},
+	"fr": {scriptLatin},
+	"es": {scriptLatin},
+	"it": {scriptLatin},
+	"pt": {scriptLatin},
+	"nl": {scriptLatin},
+	"sv": {scriptLatin},
+	"da": {scriptLatin},
+	"no": {scriptLatin},
+	"fi": {scriptLatin},
+	"pl": {scriptLatin},
+	"cs": {scriptLatin},
+	"tr": {scriptLatin},
+	"id": {scriptLatin},
+	"vi": {scriptLatin},
+}
+
+// minScriptLetters is the number of letters needed before the heuristic is
+// confident enough to flag a mismatch.
+const minScriptLetters = 8
+
+// nonProsePattern matches URLs, mentions and hashtags, which are written in
+// Latin script whatever the language of the post.
+var nonProsePattern = regexp.MustCompile(`https?://\S+|[@#][\p{L}\p{N}_.@-]+`)
+
+// dominantScript returns the script most letters of the text are written in,
+// or an empty string when there are too few letters to tell.
+func dominantScript(text string) string {
+	text = nonProsePattern.ReplaceAllString(text, " ")
+
+	counts := map[string]int{}
+	total := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for script, tables := range scriptTables {
+			if unicode.In(r, tables...) {
+				counts[script]++
+				total++
+				break
+			}
+		}
+	}
+
+	if total < minScriptLetters {
+		return ""
+	}
+
+	best := ""
+	for script, count := range counts {
+		if best == "" || count > counts[best] || (count == counts[best] && script < best) {
+			best = script
+		}
+	}
+	return best
+}
+
+// languageScriptMismatch reports whether the content is dominantly written in
+// a script that the language is not normally written in, returning the
+// detected script.
+func languageScriptMismatch(language string, content string) (string, bool) {
+	expected, ok := languageScripts[strings.ToLower(language)]
+	if !ok {
+		return "", false
+	}
+
+	script := dominantScript(content)
+	if script == "" {
+		return "", false
+	}
+
+	for _, candidate := range expected {
+		if candidate == script {
+			return script, false
+		}
+	}
+	return script, true
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageCodePattern(t *testing.T) {
+	for _, language := range []string{"en", "de", "ja", "kab", "zh-TW", "pt-BR"} {
+		assert.True(t, languageCodePattern.MatchString(language), language)
+	}
+	for _, language := range []string{"", "e", "EN", "english", "en_US", "en-us", "e1"} {
+		assert.False(t, languageCodePattern.MatchString(language), language)
+	}
+}
+
+func TestLanguageScriptMismatch(t *testing.T) {
+	script, mismatch := languageScriptMismatch("ja", "What a great day to post to the Fediverse!")
+	assert.True(t, mismatch)
+	assert.Equal(t, scriptLatin, script)
+
+	_, mismatch = languageScriptMismatch("ja", "今日はとても良い天気ですね https://example.com/a-very-long-link")
+	assert.False(t, mismatch)
+
+	script, mismatch = languageScriptMismatch("en", "Привет всем, как у вас дела?")
+	assert.True(t, mismatch)
+	assert.Equal(t, scriptCyrillic, script)
+
+	_, mismatch = languageScriptMismatch("de", "Guten Morgen aus Berlin!")
+	assert.False(t, mismatch)
+
+	// Too short to judge.
+	_, mismatch = languageScriptMismatch("ja", "lol")
+	assert.False(t, mismatch)
+
+	// Languages without a known script are never flagged.
+	_, mismatch = languageScriptMismatch("eo", "Bonan tagon al ĉiuj!")
+	assert.False(t, mismatch)
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultMaxPostCharacters is the length limit of posts on vanilla Mastodon,
+// used when the server does not advertise one.
+const defaultMaxPostCharacters = 500
+
+// defaultCharactersReservedPerURL is the length Mastodon counts every link
+// as, whatever its actual length.
+const defaultCharactersReservedPerURL = 23
+
+// lengthURLPattern matches the links Mastodon counts at a fixed length.
+var lengthURLPattern = regexp.MustCompile(`https?://\S+`)
+
+// lengthMentionPattern matches remote mentions, of which Mastodon only counts
+// the username.
+var lengthMentionPattern = regexp.MustCompile(`(@[\p{L}\p{N}_]+)@[\p{L}\p{N}.-]+[\p{L}\p{N}]`)
+
+// postLength returns the length of the content as Mastodon counts it: links
+// count as the reserved length, and remote mentions count without their
+// domain.
+func postLength(content string, reservedPerURL int) int {
+	urls := lengthURLPattern.FindAllString(content, -1)
+	content = lengthURLPattern.ReplaceAllString(content, "")
+	content = lengthMentionPattern.ReplaceAllString(content, "$1")
+	return utf8.RuneCountInString(content) + len(urls)*reservedPerURL
+}
+
+// statusLength returns the length Mastodon checks against its limit, which
+// counts the content warning together with the content.
+func statusLength(content string, spoilerText string, reservedPerURL int) int {
+	return postLength(content, reservedPerURL) + utf8.RuneCountInString(spoilerText)
+}
+
+// truncatePost shortens the content so that, with the suffix appended and
+// together with the content warning, it fits within the limit. The content is
+// cut at the last word boundary that fits, or mid-word when even the first
+// word is too long.
+func truncatePost(content string, spoilerText string, limit int, reservedPerURL int, suffix string) string {
+	limit -= utf8.RuneCountInString(spoilerText)
+	if postLength(content, reservedPerURL) <= limit {
+		return content
+	}
+
+	// Try every word boundary, longest candidate first.
+	for end := len(content); end > 0; {
+		end = strings.LastIndexFunc(content[:end], unicode.IsSpace)
+		if end <= 0 {
+			break
+		}
+		candidate := strings.TrimRightFunc(content[:end], unicode.IsSpace) + suffix
+		if postLength(candidate, reservedPerURL) <= limit {
+			return candidate
+		}
+	}
+
+	runes := []rune(content)
+	for cut := len(runes); cut > 0; cut-- {
+		candidate := string(runes[:cut]) + suffix
+		if postLength(candidate, reservedPerURL) <= limit {
+			return candidate
+		}
+	}
+	return suffix
+}
+
+// postLimits returns the maximum post length and the length links count as on
+// the server, falling back to Mastodon's defaults.
+func (c *MastodonClient) postLimits(ctx context.Context) (int, int) {
+	inst, err := c.cachedInstance(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to read the post length limit, assuming the default", map[string]interface{}{"error": err.Error()})
+		return defaultMaxPostCharacters, defaultCharactersReservedPerURL
+	}
+	return inst.maxCharacters(), inst.charactersReservedPerURL()
+}
+
+// truncateContent truncates over-limit content when `truncate_over_limit` is
+// set, reporting whether it did.
+func (c *MastodonClient) truncateContent(ctx context.Context, content string, spoilerText string) (string, bool) {
+	if !c.truncateOverLimit {
+		return content, false
+	}
+	limit, reservedPerURL := c.postLimits(ctx)
+	truncated := truncatePost(content, spoilerText, limit, reservedPerURL, c.truncationSuffix)
+	return truncated, truncated != content
+}
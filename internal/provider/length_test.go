@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostLength(t *testing.T) {
+	assert.Equal(t, 5, postLength("hello", 23))
+	assert.Equal(t, 5, postLength("héllo", 23))
+	assert.Equal(t, 5+23, postLength("see: https://example.com/a/very/long/path/indeed", 23))
+	assert.Equal(t, 10, postLength("hi @tedivm@hachyderm.io", 23))
+	assert.Equal(t, 16, postLength("hi @tedivm there", 23))
+}
+
+func TestTruncatePost(t *testing.T) {
+	long := strings.Repeat("word ", 200)
+
+	truncated := truncatePost(long, "", 500, 23, "…")
+	assert.LessOrEqual(t, postLength(truncated, 23), 500)
+	assert.True(t, strings.HasSuffix(truncated, "word…"), truncated)
+
+	// Content that fits is left alone.
+	assert.Equal(t, "short", truncatePost("short", "", 500, 23, "…"))
+
+	// Links count as the reserved length, so they can be kept even when
+	// longer than the limit.
+	withURL := "read https://example.com/" + strings.Repeat("x", 100) + " and then some more words"
+	truncated = truncatePost(withURL, "", 40, 23, "…")
+	assert.Equal(t, "read https://example.com/"+strings.Repeat("x", 100)+" and then…", truncated)
+	assert.LessOrEqual(t, postLength(truncated, 23), 40)
+
+	// A single word longer than the limit is cut mid-word.
+	truncated = truncatePost(strings.Repeat("a", 20), "", 10, 23, " [more]")
+	assert.Equal(t, "aaa [more]", truncated)
+	assert.LessOrEqual(t, postLength(truncated, 23), 10)
+
+	// The content warning counts toward the limit, so content that fits on
+	// its own is truncated when posted behind one.
+	assert.Equal(t, "one two three four five", truncatePost("one two three four five", "", 30, 23, "…"))
+	truncated = truncatePost("one two three four five", "spoilers!", 30, 23, "…")
+	assert.Equal(t, "one two three four…", truncated)
+	assert.LessOrEqual(t, statusLength(truncated, "spoilers!", 23), 30)
+}
+
+func TestPostLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uri":"pleroma.example","version":"2.7.2 (compatible; Pleroma 2.5.0)","max_toot_chars":5000}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	limit, reservedPerURL := client.postLimits(context.Background())
+	assert.Equal(t, 5000, limit)
+	assert.Equal(t, defaultCharactersReservedPerURL, reservedPerURL)
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ListMemberResource{}
+var _ resource.ResourceWithImportState = &ListMemberResource{}
+
+func NewListMemberResource() resource.Resource {
+	return &ListMemberResource{}
+}
+
+// ListMemberResource defines the resource implementation.
+type ListMemberResource struct {
+	client *MastodonClient
+}
+
+// ListMemberResourceModel describes the resource data model.
+type ListMemberResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	ListId    types.String `tfsdk:"list_id"`
+	AccountId types.String `tfsdk:"account_id"`
+}
+
+func (r *ListMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list_member"
+}
+
+func (r *ListMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource adds a single account to a list, leaving the other members alone. " +
+			"Only accounts the authenticated account follows can be added to a list. " +
+			"Do not combine it with a `mastodon_list_membership` for the same list that has `prune` enabled, as that removes the account again.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the member, in the form `list_id:account_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"list_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the list to add the account to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the account to add to the list.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ListMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ListMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ListMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping list member creation.")
+		data.Id = types.StringValue(validateOnlyID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	err := r.client.addListMember(ctx, data.ListId.ValueString(), data.AccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add account to list, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.ListId.ValueString() + ":" + data.AccountId.ValueString())
+
+	tflog.Trace(ctx, "created a list member")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ListMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "list member was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	members, err := r.client.listMembers(ctx, data.ListId.ValueString())
+	if isNotFound(err) {
+		// The list was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read list members, got error: %s", err))
+		return
+	}
+
+	if !slices.Contains(members, data.AccountId.ValueString()) {
+		// The account was removed from the list outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data ListMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ListMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping list member deletion.")
+		return
+	}
+
+	err := r.client.RemoveFromList(ctx, mastodon.ID(data.ListId.ValueString()), mastodon.ID(data.AccountId.ValueString()))
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove account from list, got error: %s", err))
+		return
+	}
+}
+
+func (r *ListMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	listId, accountId, found := strings.Cut(req.ID, ":")
+	if !found || listId == "" || accountId == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier in the form list_id:account_id, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("list_id"), listId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), accountId)...)
+}
+
+// addListMember adds an account to a list. Mastodon rejects accounts that are
+// already in the list, which is treated as success.
+func (c *MastodonClient) addListMember(ctx context.Context, listID string, accountID string) error {
+	err := c.AddToList(ctx, mastodon.ID(listID), mastodon.ID(accountID))
+	if err == nil {
+		return nil
+	}
+
+	members, listErr := c.listMembers(ctx, listID)
+	if listErr == nil && slices.Contains(members, accountID) {
+		tflog.Debug(ctx, "account is already in the list", map[string]interface{}{"list_id": listID, "account_id": accountID})
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddListMember(t *testing.T) {
+	server, members := newListServer(t, "1")
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	err := client.addListMember(context.Background(), "42", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, members())
+
+	// Adding an account that is already a member succeeds.
+	err = client.addListMember(context.Background(), "42", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, members())
+
+	// A missing list is still an error.
+	err = client.addListMember(context.Background(), "43", "1")
+	assert.True(t, isNotFound(err))
+}
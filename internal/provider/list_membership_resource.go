@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// listMembershipBatchSize is the number of accounts added to or removed from
+// a list per request.
+const listMembershipBatchSize = 40
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ListMembershipResource{}
+var _ resource.ResourceWithImportState = &ListMembershipResource{}
+
+func NewListMembershipResource() resource.Resource {
+	return &ListMembershipResource{}
+}
+
+// ListMembershipResource defines the resource implementation.
+type ListMembershipResource struct {
+	client *MastodonClient
+}
+
+// ListMembershipResourceModel describes the resource data model.
+type ListMembershipResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	ListId     types.String `tfsdk:"list_id"`
+	AccountIds types.Set    `tfsdk:"account_ids"`
+	Prune      types.Bool   `tfsdk:"prune"`
+}
+
+func (r *ListMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list_membership"
+}
+
+func (r *ListMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource manages the members of a list as a whole, adding and removing accounts so the list matches `account_ids`. " +
+			"Only accounts the authenticated account follows can be added to a list. Destroying the resource removes the accounts in `account_ids` from the list.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the membership, the same as `list_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"list_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the list to manage.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the accounts that should be members of the list.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"prune": schema.BoolAttribute{
+				MarkdownDescription: "Remove members of the list that are not in `account_ids`, so the list matches it exactly. " +
+					"When `false`, accounts added to the list outside of Terraform are left alone. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *ListMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ListMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ListMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping list membership changes.")
+		data.Id = types.StringValue(validateOnlyID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	var desired []string
+	resp.Diagnostics.Append(data.AccountIds.ElementsAs(ctx, &desired, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.reconcileListMembers(ctx, data.ListId.ValueString(), desired, nil, data.Prune.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update list members, got error: %s", err))
+		return
+	}
+
+	data.Id = data.ListId
+
+	tflog.Trace(ctx, "created a list membership")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ListMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "list membership was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	members, err := r.client.listMembers(ctx, data.ListId.ValueString())
+	if isNotFound(err) {
+		// The list was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read list members, got error: %s", err))
+		return
+	}
+
+	// Without pruning, only the accounts Terraform manages are tracked.
+	if !data.Prune.ValueBool() {
+		var managed []string
+		resp.Diagnostics.Append(data.AccountIds.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		members = slices.DeleteFunc(members, func(id string) bool { return !slices.Contains(managed, id) })
+	}
+
+	accountIds, diags := types.SetValueFrom(ctx, types.StringType, members)
+	resp.Diagnostics.Append(diags...)
+	data.AccountIds = accountIds
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ListMembershipResourceModel
+	var state ListMembershipResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping list membership changes.")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	var desired []string
+	var managed []string
+	resp.Diagnostics.Append(data.AccountIds.ElementsAs(ctx, &desired, false)...)
+	resp.Diagnostics.Append(state.AccountIds.ElementsAs(ctx, &managed, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.reconcileListMembers(ctx, data.ListId.ValueString(), desired, managed, data.Prune.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update list members, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ListMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping list membership changes.")
+		return
+	}
+
+	var managed []string
+	resp.Diagnostics.Append(data.AccountIds.ElementsAs(ctx, &managed, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Removing every managed account, and nothing else.
+	err := r.client.reconcileListMembers(ctx, data.ListId.ValueString(), nil, managed, false)
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove list members, got error: %s", err))
+		return
+	}
+}
+
+func (r *ListMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Imported lists are taken over as a whole.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("list_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("prune"), true)...)
+}
+
+// listMembers returns the IDs of the accounts in the list, sorted.
+func (c *MastodonClient) listMembers(ctx context.Context, listID string) ([]string, error) {
+	accounts, err := c.GetListAccounts(ctx, mastodon.ID(listID))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, string(account.ID))
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// reconcileListMembers adds the desired accounts missing from the list and
+// removes the members that should not be in it: every other member when
+// pruning, otherwise only the previously managed accounts no longer desired.
+func (c *MastodonClient) reconcileListMembers(ctx context.Context, listID string, desired []string, managed []string, prune bool) error {
+	members, err := c.listMembers(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	var add []mastodon.ID
+	for _, id := range desired {
+		if !slices.Contains(members, id) {
+			add = append(add, mastodon.ID(id))
+		}
+	}
+
+	var remove []mastodon.ID
+	for _, id := range members {
+		if slices.Contains(desired, id) {
+			continue
+		}
+		if prune || slices.Contains(managed, id) {
+			remove = append(remove, mastodon.ID(id))
+		}
+	}
+
+	tflog.Debug(ctx, "reconciling list members", map[string]interface{}{"list_id": listID, "add": len(add), "remove": len(remove)})
+
+	for start := 0; start < len(remove); start += listMembershipBatchSize {
+		batch := remove[start:min(start+listMembershipBatchSize, len(remove))]
+		if err := c.RemoveFromList(ctx, mastodon.ID(listID), batch...); err != nil {
+			return err
+		}
+	}
+	for start := 0; start < len(add); start += listMembershipBatchSize {
+		batch := add[start:min(start+listMembershipBatchSize, len(add))]
+		if err := c.AddToList(ctx, mastodon.ID(listID), batch...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
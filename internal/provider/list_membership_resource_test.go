@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+// newListServer serves the accounts endpoint of a single list, starting with
+// the given members.
+func newListServer(t *testing.T, members ...string) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path != "/api/v1/lists/42/accounts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// ParseForm ignores the body of DELETE requests.
+		body, _ := io.ReadAll(r.Body)
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			accounts := make([]mastodon.Account, 0, len(members))
+			for _, id := range members {
+				accounts = append(accounts, mastodon.Account{ID: mastodon.ID(id)})
+			}
+			_ = json.NewEncoder(w).Encode(accounts)
+		case http.MethodPost:
+			// Mastodon rejects accounts that are already members.
+			if slices.ContainsFunc(form["account_ids"], func(id string) bool { return slices.Contains(members, id) }) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"error":"Validation failed: Account has already been taken"}`))
+				return
+			}
+			members = append(members, form["account_ids"]...)
+			_, _ = w.Write([]byte(`{}`))
+		case http.MethodDelete:
+			members = slices.DeleteFunc(members, func(id string) bool { return slices.Contains(form["account_ids"], id) })
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		sorted := slices.Clone(members)
+		slices.Sort(sorted)
+		return sorted
+	}
+}
+
+func TestReconcileListMembers(t *testing.T) {
+	server, members := newListServer(t, "1", "2", "3")
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	// Without pruning, only the previously managed account 2 is removed.
+	err := client.reconcileListMembers(context.Background(), "42", []string{"1", "4"}, []string{"1", "2"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "4"}, members())
+
+	// Pruning removes every other member.
+	err = client.reconcileListMembers(context.Background(), "42", []string{"4", "5"}, []string{"1", "4"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4", "5"}, members())
+
+	listed, err := client.listMembers(context.Background(), "42")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4", "5"}, listed)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/mattn/go-mastodon"
+)
+
+// mediaCache remembers the media uploaded during a run by the hash of its
+// content, so identical media is only uploaded once when
+// `deduplicate_media` is set.
+type mediaCache struct {
+	mu      sync.Mutex
+	entries map[string]*mediaCacheEntry
+
+	// attached holds the media IDs posts were created with during the run.
+	// Identical media shares one ID, but Mastodon attaches media to a
+	// single post.
+	attached map[mastodon.ID]bool
+}
+
+// mediaCacheEntry is an upload that is done once its channel is closed.
+type mediaCacheEntry struct {
+	done       chan struct{}
+	attachment *mastodon.Attachment
+	err        error
+}
+
+func newMediaCache() *mediaCache {
+	return &mediaCache{entries: map[string]*mediaCacheEntry{}, attached: map[mastodon.ID]bool{}}
+}
+
+// claim records that a post is being created with the media. When another
+// post of the run already has some of it, nothing is recorded and the first
+// such ID is returned.
+func (m *mediaCache) claim(ids []mastodon.ID) (mastodon.ID, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		if m.attached[id] {
+			return id, false
+		}
+	}
+	for _, id := range ids {
+		m.attached[id] = true
+	}
+	return "", true
+}
+
+// release forgets a claim whose post could not be created.
+func (m *mediaCache) release(ids []mastodon.ID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		delete(m.attached, id)
+	}
+}
+
+// mediaCacheKey hashes the content of the media along with its description
+// and focal point, since those are stored on the upload as well.
+func mediaCacheKey(content []byte, description string, focus string) string {
+	h := sha256.New()
+	h.Write(content)
+	h.Write([]byte{0})
+	h.Write([]byte(description))
+	h.Write([]byte{0})
+	h.Write([]byte(focus))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// upload returns the media uploaded for the key, calling upload the first
+// time the key is seen and reporting whether the media was reused. Resources
+// are created in parallel, so callers with the same key wait for the first
+// upload. A failed upload is forgotten, so the next caller tries again.
+func (m *mediaCache) upload(ctx context.Context, key string, upload func() (*mastodon.Attachment, error)) (*mastodon.Attachment, bool, error) {
+	for {
+		m.mu.Lock()
+		entry, ok := m.entries[key]
+		if !ok {
+			entry = &mediaCacheEntry{done: make(chan struct{})}
+			m.entries[key] = entry
+			m.mu.Unlock()
+
+			entry.attachment, entry.err = upload()
+			if entry.err != nil {
+				m.mu.Lock()
+				delete(m.entries, key)
+				m.mu.Unlock()
+			}
+			close(entry.done)
+			return entry.attachment, false, entry.err
+		}
+		m.mu.Unlock()
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if entry.err == nil {
+			return entry.attachment, true, nil
+		}
+	}
+}
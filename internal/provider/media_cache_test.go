@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaCache_UploadsOnce(t *testing.T) {
+	cache := newMediaCache()
+	key := mediaCacheKey([]byte("pixel"), "A single pixel", "")
+
+	var uploads atomic.Int32
+	var wg sync.WaitGroup
+	ids := make([]mastodon.ID, 10)
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attachment, _, err := cache.upload(context.Background(), key, func() (*mastodon.Attachment, error) {
+				uploads.Add(1)
+				return &mastodon.Attachment{ID: "7"}, nil
+			})
+			assert.NoError(t, err)
+			ids[i] = attachment.ID
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), uploads.Load())
+	for _, id := range ids {
+		assert.Equal(t, mastodon.ID("7"), id)
+	}
+
+	// The description is part of the key.
+	assert.NotEqual(t, key, mediaCacheKey([]byte("pixel"), "Another pixel", ""))
+}
+
+func TestMediaCache_RetriesFailedUpload(t *testing.T) {
+	cache := newMediaCache()
+
+	_, _, err := cache.upload(context.Background(), "key", func() (*mastodon.Attachment, error) {
+		return nil, errors.New("server error")
+	})
+	assert.Error(t, err)
+
+	attachment, reused, err := cache.upload(context.Background(), "key", func() (*mastodon.Attachment, error) {
+		return &mastodon.Attachment{ID: "8"}, nil
+	})
+	assert.NoError(t, err)
+	assert.False(t, reused)
+	assert.Equal(t, mastodon.ID("8"), attachment.ID)
+}
+
+func TestMediaCache_Claim(t *testing.T) {
+	cache := newMediaCache()
+
+	_, ok := cache.claim([]mastodon.ID{"1", "2"})
+	assert.True(t, ok)
+
+	// Media can only be attached to one post, so no part of a second claim
+	// is recorded.
+	id, ok := cache.claim([]mastodon.ID{"3", "2"})
+	assert.False(t, ok)
+	assert.Equal(t, mastodon.ID("2"), id)
+	_, ok = cache.claim([]mastodon.ID{"3"})
+	assert.True(t, ok)
+
+	// Media of a post that could not be created can be used again.
+	cache.release([]mastodon.ID{"1", "2"})
+	_, ok = cache.claim([]mastodon.ID{"2"})
+	assert.True(t, ok)
+}
+
+func TestUploadMedia_Deduplicates(t *testing.T) {
+	var uploads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/media" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n := uploads.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":"%d","url":"https://example.com/%d.png"}`, n, n)
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL}), mediaCache: newMediaCache()}
+
+	first, err := client.uploadMedia(context.Background(), bytes.NewReader([]byte("pixel")), "A single pixel", "")
+	assert.NoError(t, err)
+	second, err := client.uploadMedia(context.Background(), bytes.NewReader([]byte("pixel")), "A single pixel", "")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, int32(1), uploads.Load())
+
+	// Different content is uploaded again.
+	third, err := client.uploadMedia(context.Background(), bytes.NewReader([]byte("other")), "A single pixel", "")
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.ID, third.ID)
+	assert.Equal(t, int32(2), uploads.Load())
+}
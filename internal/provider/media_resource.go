@@ -0,0 +1,477 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MediaResource{}
+var _ resource.ResourceWithModifyPlan = &MediaResource{}
+
+func NewMediaResource() resource.Resource {
+	return &MediaResource{}
+}
+
+// MediaResource defines the resource implementation.
+type MediaResource struct {
+	client *MastodonClient
+}
+
+// MediaResourceModel describes the resource data model.
+type MediaResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	File        types.String `tfsdk:"file"`
+	Content     types.String `tfsdk:"content"`
+	Description types.String `tfsdk:"description"`
+	Focus       types.String `tfsdk:"focus"`
+	Url         types.String `tfsdk:"url"`
+}
+
+func (r *MediaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_media"
+}
+
+func (r *MediaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource uploads a media file, such as an image or a video, so it can be attached to posts with the `media_ids` attribute of `mastodon_post`. " +
+			"Mastodon only lets media be read or edited until it is attached to a post, and deletes media that is never attached after a while. " +
+			"Media of a type or size the instance does not accept fails the plan instead of the upload.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the uploaded media, to use in `media_ids`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"file": schema.StringAttribute{
+				MarkdownDescription: "Path to the media file to upload. Exactly one of `file` and `content` must be set. Changing it uploads the media again.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content")),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded media to upload, e.g. from `filebase64()`. Changing it uploads the media again.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Alt text describing the media for people who cannot see it. " +
+					"When not set and the provider's `derive_alt_from_filename` is enabled, a readable form of the name of `file` is used.",
+				Optional: true,
+				Computed: true,
+			},
+			"focus": schema.StringAttribute{
+				MarkdownDescription: "Focal point of the image used when cropping previews, as `x,y` coordinates between `-1.0` and `1.0`, e.g. `0.0,0.5`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(focusPattern, "must be two coordinates between -1.0 and 1.0 separated by a comma"),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of the uploaded media. Null while the server is still processing it.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *MediaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MediaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config MediaResourceModel
+	var plan MediaResourceModel
+	var state MediaResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only media about to be uploaded is checked, and files that do not
+	// exist yet are checked when they are uploaded.
+	upload := req.State.Raw.IsNull() || !plan.File.Equal(state.File) || !plan.Content.Equal(state.Content)
+	if upload && !plan.File.IsUnknown() && !plan.Content.IsUnknown() {
+		if _, err := os.Stat(plan.File.ValueString()); plan.File.IsNull() || err == nil {
+			r.checkLimits(ctx, plan, &resp.Diagnostics)
+		}
+	}
+
+	if !config.Description.IsNull() {
+		return
+	}
+
+	// Media without a description has no alt text, unless it is derived
+	// from the file name.
+	plan.Description = types.StringNull()
+	if r.client.deriveAltFromFilename && !plan.File.IsNull() {
+		plan.Description = types.StringUnknown()
+		if !plan.File.IsUnknown() {
+			plan.Description = stringValueOrNull(altFromFilename(plan.File.ValueString()))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *MediaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MediaResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	file, err := data.reader()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Media", err.Error())
+		return
+	}
+	defer file.Close()
+
+	r.checkLimits(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping media upload.")
+		data.Id = types.StringValue(validateOnlyID)
+		data.Url = types.StringNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	attachment, err := r.client.uploadMedia(ctx, file, data.Description.ValueString(), data.Focus.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload media, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(string(attachment.ID))
+	data.Url = stringValueOrNull(attachment.URL)
+
+	tflog.Trace(ctx, "uploaded media")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MediaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MediaResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "media was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	attachment, err := r.client.getMedia(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		// Media attached to a post can no longer be read, so the state is
+		// kept as it is.
+		tflog.Debug(ctx, "media is attached to a post or was removed: keeping state.", map[string]interface{}{"id": data.Id.ValueString()})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read media, got error: %s", err))
+		return
+	}
+
+	data.Description = stringValueOrNull(attachment.Description)
+	data.Url = stringValueOrNull(attachment.URL)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MediaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MediaResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping media update.")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	attachment, err := r.client.updateMedia(ctx, data.Id.ValueString(), data.Description.ValueString(), data.Focus.ValueString())
+	if isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Update Media",
+			fmt.Sprintf("Media %s is attached to a post or no longer exists, and Mastodon cannot edit it. "+
+				"Edit the post's media instead, or replace this resource with -replace.", data.Id.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update media, got error: %s", err))
+		return
+	}
+
+	data.Url = stringValueOrNull(attachment.URL)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MediaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MediaResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping media deletion.")
+		return
+	}
+
+	// Identical media shares one ID with deduplicate_media, and other
+	// resources may still use it, so it is left for the server to remove
+	// once it is unattached.
+	if r.client.mediaCache != nil {
+		tflog.Debug(ctx, "deduplicate_media is enabled: leaving media that may be shared to the server.", map[string]interface{}{"id": data.Id.ValueString()})
+		return
+	}
+
+	// Media attached to a post cannot be deleted and goes away with the
+	// post, while unattached media is removed by the server eventually, so
+	// deleting is only attempted.
+	r.client.deleteMedia(ctx, []mastodon.ID{mastodon.ID(data.Id.ValueString())})
+}
+
+// reader opens the media to upload, from either the file or the base64
+// encoded content.
+func (data *MediaResourceModel) reader() (io.ReadCloser, error) {
+	if !data.File.IsNull() {
+		return os.Open(data.File.ValueString())
+	}
+
+	content, err := base64.StdEncoding.DecodeString(data.Content.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("content is not valid base64: %w", err)
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// uploadMedia uploads the media, or reuses identical media uploaded earlier in
+// the run when `deduplicate_media` is set.
+func (c *MastodonClient) uploadMedia(ctx context.Context, file io.Reader, description string, focus string) (*mastodon.Attachment, error) {
+	if c.mediaCache == nil {
+		return c.UploadMediaFromMedia(ctx, &mastodon.Media{File: file, Description: description, Focus: focus})
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	attachment, reused, err := c.mediaCache.upload(ctx, mediaCacheKey(content, description, focus), func() (*mastodon.Attachment, error) {
+		return c.UploadMediaFromMedia(ctx, &mastodon.Media{File: bytes.NewReader(content), Description: description, Focus: focus})
+	})
+	if reused {
+		tflog.Debug(ctx, "reusing identical media uploaded earlier", map[string]interface{}{"id": string(attachment.ID)})
+	}
+	return attachment, err
+}
+
+// releaseMedia forgets that a post was being created with the media, after
+// creating it failed.
+func (c *MastodonClient) releaseMedia(ids []mastodon.ID) {
+	if c.mediaCache != nil {
+		c.mediaCache.release(ids)
+	}
+}
+
+// checkLimits adds an error when the instance does not accept the media to
+// upload. Media that cannot be read is reported when it is uploaded.
+func (r *MediaResource) checkLimits(ctx context.Context, data MediaResourceModel, diags *diag.Diagnostics) {
+	mimeType, size, err := data.inspect()
+	if err != nil {
+		return
+	}
+
+	if err := r.client.checkMediaLimits(ctx, mimeType, size); err != nil {
+		attribute := path.Root("file")
+		if data.File.IsNull() {
+			attribute = path.Root("content")
+		}
+		diags.AddAttributeError(attribute, "Media Not Accepted by Server", fmt.Sprintf("The media cannot be uploaded: %s.", err))
+	}
+}
+
+// inspect returns the MIME type and size of the media to upload. The type is
+// sniffed from the content, falling back to the file extension, and is empty
+// when neither identifies it.
+func (data *MediaResourceModel) inspect() (string, int64, error) {
+	file, err := data.reader()
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", 0, err
+	}
+	rest, err := io.Copy(io.Discard, file)
+	if err != nil {
+		return "", 0, err
+	}
+
+	mimeType := http.DetectContentType(head[:n])
+	if mimeType == "application/octet-stream" && !data.File.IsNull() {
+		mimeType = mime.TypeByExtension(filepath.Ext(data.File.ValueString()))
+	}
+	if mimeType == "application/octet-stream" {
+		mimeType = ""
+	}
+	mimeType, _, _ = mime.ParseMediaType(mimeType)
+	return mimeType, int64(n) + rest, nil
+}
+
+// checkMediaLimits returns an error when the instance configuration does not
+// accept media of the type or size. Media is accepted when the configuration
+// cannot be read or the type is not known.
+func (c *MastodonClient) checkMediaLimits(ctx context.Context, mimeType string, size int64) error {
+	inst, err := c.cachedInstance(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to read the media limits, skipping the check", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	media := inst.mediaAttachments()
+
+	if mimeType != "" && len(media.SupportedMimeTypes) > 0 && !slices.Contains(media.SupportedMimeTypes, mimeType) {
+		return fmt.Errorf("the server does not accept media of type %s", mimeType)
+	}
+
+	// Mastodon applies the video limit to audio as well.
+	limit := max(media.ImageSizeLimit, media.VideoSizeLimit)
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		limit = media.ImageSizeLimit
+	case strings.HasPrefix(mimeType, "video/"), strings.HasPrefix(mimeType, "audio/"):
+		limit = media.VideoSizeLimit
+	}
+	if limit > 0 && size > int64(limit) {
+		return fmt.Errorf("the media is %d bytes, more than the server's limit of %d bytes", size, limit)
+	}
+	return nil
+}
+
+// altFromFilename turns a file name into alt text by dropping the directory
+// and extension and replacing separators with spaces, e.g. "my cat photo" for
+// "photos/my_cat_photo.jpg".
+func altFromFilename(name string) string {
+	base := filepath.Base(name)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	base = strings.NewReplacer("_", " ", "-", " ", ".", " ").Replace(base)
+	return strings.Join(strings.Fields(base), " ")
+}
+
+// getMedia reads media that is not attached to a post yet.
+func (c *MastodonClient) getMedia(ctx context.Context, id string) (*mastodon.Attachment, error) {
+	// The mastodon library cannot read or update media, so the endpoints are
+	// called directly.
+	var attachment mastodon.Attachment
+	if err := c.doAPI(ctx, http.MethodGet, "/api/v1/media/"+url.PathEscape(id), nil, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// updateMedia updates the description and focal point of media that is not
+// attached to a post yet.
+func (c *MastodonClient) updateMedia(ctx context.Context, id string, description string, focus string) (*mastodon.Attachment, error) {
+	params := url.Values{}
+	params.Set("description", description)
+	if focus != "" {
+		params.Set("focus", focus)
+	}
+
+	var attachment mastodon.Attachment
+	if err := c.doAPI(ctx, http.MethodPut, "/api/v1/media/"+url.PathEscape(id), params, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
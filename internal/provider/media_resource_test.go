@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccMediaResource(t *testing.T) {
+	image, err := filepath.Abs("testdata/pixel.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "mastodon_media" "test" {
+  file        = %q
+  description = "A single pixel"
+}
+`, image),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mastodon_media.test", "id"),
+					resource.TestCheckResourceAttr("mastodon_media.test", "description", "A single pixel"),
+				),
+			},
+			// Update the description in place, then attach the media to a post.
+			{
+				Config: fmt.Sprintf(`
+resource "mastodon_media" "test" {
+  file        = %q
+  description = "A very small image"
+}
+
+resource "mastodon_post" "test" {
+  content   = "Post With Uploaded Media"
+  media_ids = [mastodon_media.test.id]
+}
+`, image),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_media.test", "description", "A very small image"),
+					resource.TestCheckResourceAttrPair("mastodon_post.test", "media_ids.0", "mastodon_media.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestMediaResourceModel_Reader(t *testing.T) {
+	data := MediaResourceModel{
+		File:    types.StringNull(),
+		Content: types.StringValue(base64.StdEncoding.EncodeToString([]byte("pixel"))),
+	}
+	reader, err := data.reader()
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	data.Content = types.StringValue("not base64!")
+	_, err = data.reader()
+	assert.ErrorContains(t, err, "not valid base64")
+}
+
+func TestAltFromFilename(t *testing.T) {
+	for name, want := range map[string]string{
+		"my_cat_photo.jpg":           "my cat photo",
+		"photos/sunset-over.the.png": "sunset over the",
+		"/tmp/a__b--c.jpeg":          "a b c",
+		"README":                     "README",
+		".png":                       "",
+	} {
+		assert.Equal(t, want, altFromFilename(name), name)
+	}
+}
+
+func TestMediaResource_ModifyPlanDerivesAlt(t *testing.T) {
+	var schemaResp fwresource.SchemaResponse
+	(&MediaResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	objectType, ok := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatal("schema is not an object")
+	}
+
+	object := func(description tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		values["file"] = tftypes.NewValue(tftypes.String, "images/my_cat_photo.jpg")
+		values["description"] = description
+		return tftypes.NewValue(objectType, values)
+	}
+
+	modifyPlan := func(derive bool, description tftypes.Value) types.String {
+		// Terraform plans an unset computed attribute as unknown.
+		planned := description
+		if description.IsNull() {
+			planned = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		}
+		req := fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: object(description)},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: object(planned)},
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+		r := &MediaResource{client: &MastodonClient{deriveAltFromFilename: derive}}
+		r.ModifyPlan(context.Background(), req, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var plan MediaResourceModel
+		resp.Diagnostics.Append(resp.Plan.Get(context.Background(), &plan)...)
+		return plan.Description
+	}
+
+	assert.Equal(t, types.StringValue("my cat photo"), modifyPlan(true, tftypes.NewValue(tftypes.String, nil)))
+	assert.Equal(t, types.StringNull(), modifyPlan(false, tftypes.NewValue(tftypes.String, nil)))
+
+	// An explicit description is never replaced.
+	assert.Equal(t, types.StringValue("A tabby asleep on a keyboard"), modifyPlan(true, tftypes.NewValue(tftypes.String, "A tabby asleep on a keyboard")))
+}
+
+func TestMediaResource_CheckLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uri":"example.com","configuration":{"media_attachments":{"supported_mime_types":["image/png","image/jpeg"],"image_size_limit":80,"video_size_limit":1000}}}`))
+	}))
+	defer server.Close()
+
+	r := &MediaResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+	check := func(data MediaResourceModel) diag.Diagnostics {
+		var diags diag.Diagnostics
+		r.checkLimits(context.Background(), data, &diags)
+		return diags
+	}
+
+	// testdata/pixel.png is a PNG under the image limit.
+	diags := check(MediaResourceModel{File: types.StringValue("testdata/pixel.png"), Content: types.StringNull()})
+	assert.False(t, diags.HasError(), diags)
+
+	diags = check(MediaResourceModel{
+		File:    types.StringNull(),
+		Content: types.StringValue(base64.StdEncoding.EncodeToString([]byte("GIF89a\x01\x00\x01\x00"))),
+	})
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "Media Not Accepted by Server", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "does not accept media of type image/gif")
+	}
+
+	oversize := append([]byte("\xff\xd8\xff"), make([]byte, 100)...)
+	diags = check(MediaResourceModel{
+		File:    types.StringNull(),
+		Content: types.StringValue(base64.StdEncoding.EncodeToString(oversize)),
+	})
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Detail(), "103 bytes, more than the server's limit of 80 bytes")
+	}
+}
+
+func TestUpdateMedia(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/media/7" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+			return
+		}
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":"7","url":"https://example.com/7.png","description":%q,"meta":{"focus":{"x":0,"y":0.5}}}`, r.PostForm.Get("description")+"|"+r.PostForm.Get("focus"))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	attachment, err := client.updateMedia(context.Background(), "7", "A single pixel", "0.0,0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "A single pixel|0.0,0.5", attachment.Description)
+	assert.Equal(t, "https://example.com/7.png", attachment.URL)
+
+	// Media attached to a post is not found.
+	_, err = client.updateMedia(context.Background(), "8", "A single pixel", "")
+	assert.True(t, isNotFound(err))
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// remoteMentionPattern matches fully qualified mentions such as
+// `@someone@example.org`. Mentions of local accounts need no resolution and
+// are not matched.
+var remoteMentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_/@])@([\p{L}\p{N}_.-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+)`)
+
+// extractRemoteMentions returns the handles, without the leading `@`, of the
+// remote accounts mentioned in the content. Duplicates are dropped and the
+// order of first appearance is kept.
+func extractRemoteMentions(content string) []string {
+	var handles []string
+	seen := map[string]bool{}
+	for _, match := range remoteMentionPattern.FindAllStringSubmatch(content, -1) {
+		// A trailing period ends the sentence rather than the domain.
+		handle := strings.TrimRight(match[1], ".")
+		key := strings.ToLower(handle)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// unresolvedMentions asks the server to resolve every remote account mentioned
+// in the content, fetching accounts it has not seen before so the mentions
+// federate. It returns the handles that could not be resolved.
+func (c *MastodonClient) unresolvedMentions(ctx context.Context, content string) []string {
+	var unresolved []string
+	for _, handle := range extractRemoteMentions(content) {
+		tflog.Debug(ctx, "resolving mention", map[string]interface{}{"handle": c.logHandle(handle)})
+		results, err := c.Search(ctx, "@"+handle, true)
+		if err != nil || !containsAccount(results.Accounts, handle) {
+			unresolved = append(unresolved, handle)
+		}
+	}
+	return unresolved
+}
+
+// containsAccount reports whether one of the accounts has the given handle.
+func containsAccount(accounts []*mastodon.Account, handle string) bool {
+	for _, account := range accounts {
+		if strings.EqualFold(account.Acct, handle) {
+			return true
+		}
+	}
+	return false
+}
+
+// leadingMentionsPattern matches the mentions, local or remote, that open the
+// content of a reply.
+var leadingMentionsPattern = regexp.MustCompile(`^\s*(?:@[\p{L}\p{N}_.-]+(?:@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+)?\s+)+`)
+
+// withoutLeadingMentions strips the mentions that open the content.
+func withoutLeadingMentions(content string) string {
+	return strings.TrimSpace(leadingMentionsPattern.ReplaceAllString(content, ""))
+}
+
+// prependMention prepends a mention of acct to the content unless the content
+// already mentions it or acct is the author's own account, which Mastodon
+// clients never mention in replies.
+func prependMention(content string, acct string, self string) string {
+	if acct == "" || strings.EqualFold(acct, self) {
+		return content
+	}
+
+	mention := "@" + acct
+	for _, token := range strings.Fields(content) {
+		if strings.EqualFold(strings.TrimRight(token, ".,:;!?"), mention) {
+			return content
+		}
+	}
+	return mention + " " + content
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractRemoteMentions(t *testing.T) {
+	mentions := extractRemoteMentions("Thanks @tedivm@hachyderm.io and @Gargron@mastodon.social. cc @local, @tedivm@hachyderm.io again, mail me at me@example.com")
+	assert.Equal(t, []string{"tedivm@hachyderm.io", "Gargron@mastodon.social"}, mentions)
+
+	assert.Empty(t, extractRemoteMentions("No mentions here, see https://example.com/@someone@example.org"))
+}
+
+func TestUnresolvedMentions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results := mastodon.Results{}
+		if r.URL.Query().Get("q") == "@tedivm@hachyderm.io" && r.URL.Query().Get("resolve") == "true" {
+			results.Accounts = []*mastodon.Account{{ID: "1", Acct: "tedivm@hachyderm.io"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(results)
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	unresolved := client.unresolvedMentions(context.Background(), "Hello @tedivm@hachyderm.io and @nobody@gone.example!")
+	assert.Equal(t, []string{"nobody@gone.example"}, unresolved)
+}
+
+func TestPrependMention(t *testing.T) {
+	assert.Equal(t, "@tedivm@hachyderm.io Thanks!", prependMention("Thanks!", "tedivm@hachyderm.io", "me"))
+	assert.Equal(t, "Thanks @TEDIVM@hachyderm.io!", prependMention("Thanks @TEDIVM@hachyderm.io!", "tedivm@hachyderm.io", "me"))
+	// Replies to your own posts do not mention yourself.
+	assert.Equal(t, "Part two", prependMention("Part two", "me", "me"))
+}
+
+func TestWithoutLeadingMentions(t *testing.T) {
+	assert.Equal(t, "Thanks!", withoutLeadingMentions("@tedivm@hachyderm.io Thanks!"))
+	assert.Equal(t, "Thanks!", withoutLeadingMentions("@tedivm @someone Thanks!"))
+	assert.Equal(t, "Thanks @tedivm!", withoutLeadingMentions("Thanks @tedivm!"))
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NotificationConsumerResource{}
+
+func NewNotificationConsumerResource() resource.Resource {
+	return &NotificationConsumerResource{}
+}
+
+// NotificationConsumerResource defines the resource implementation.
+type NotificationConsumerResource struct {
+	client *MastodonClient
+}
+
+// NotificationConsumerResourceModel describes the resource data model.
+type NotificationConsumerResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	MaxEvents      types.Int64  `tfsdk:"max_events"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	EventsSeen     types.Int64  `tfsdk:"events_seen"`
+	LastReadId     types.String `tfsdk:"last_read_id"`
+}
+
+func (r *NotificationConsumerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_consumer"
+}
+
+func (r *NotificationConsumerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource consumes new notifications once, when it is created. " +
+			"It listens to the authenticated account's notification stream until `max_events` notifications arrive or `timeout_seconds` pass, " +
+			"then advances the notifications read marker past the last one it saw.\n\n" +
+			"This is a one-shot consumption, not a daemon: nothing is consumed while Terraform is not running, and notifications are not consumed again until the resource is replaced. " +
+			"Change `triggers` to consume again on the next apply. Destroying the resource leaves the read marker where it is.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the consumption run.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_events": schema.Int64Attribute{
+				MarkdownDescription: "Stop after this many notifications have been seen. Defaults to `20`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(20),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Stop listening after this many seconds, even if fewer than `max_events` notifications arrived. Defaults to `30`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 900),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that, when changed, replace the resource and consume notifications again.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"events_seen": schema.Int64Attribute{
+				MarkdownDescription: "The number of notifications seen while listening.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_read_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the last notification seen, which the read marker was advanced to. Null if no notifications arrived.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NotificationConsumerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NotificationConsumerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NotificationConsumerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping notification consumption.")
+		data.Id = types.StringValue(validateOnlyID)
+		data.EventsSeen = types.Int64Value(0)
+		data.LastReadId = types.StringNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	streamCtx, cancel := context.WithTimeout(ctx, time.Duration(data.TimeoutSeconds.ValueInt64())*time.Second)
+	defer cancel()
+
+	events, err := r.client.streamingUser(streamCtx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stream notifications, got error: %s", err))
+		return
+	}
+
+	seen, lastID, err := consumeNotifications(streamCtx, events, int(data.MaxEvents.ValueInt64()))
+
+	// The stream keeps reconnecting until its context is cancelled, so it is
+	// stopped and drained before moving on.
+	cancel()
+	go func() {
+		for range events {
+		}
+	}()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stream notifications, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "consumed notifications", map[string]interface{}{"events_seen": seen})
+
+	data.Id = types.StringValue(strconv.FormatInt(time.Now().UnixNano(), 10))
+	data.EventsSeen = types.Int64Value(int64(seen))
+	data.LastReadId = types.StringNull()
+
+	if seen > 0 {
+		err := r.client.advanceNotificationsMarker(ctx, lastID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update notifications marker, got error: %s", err))
+			return
+		}
+		data.LastReadId = types.StringValue(string(lastID))
+	}
+
+	tflog.Trace(ctx, "created a notification consumer")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationConsumerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Consumption happens once, on create, so there is nothing to refresh.
+}
+
+func (r *NotificationConsumerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data NotificationConsumerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationConsumerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The read marker is left where it is.
+}
+
+// streamingUser opens the authenticated account's stream. The provider's
+// request timeout limits whole responses, which would cut the stream off, so
+// the stream is only bounded by the context.
+func (c *MastodonClient) streamingUser(ctx context.Context) (chan mastodon.Event, error) {
+	stream := *c.Client
+	stream.Timeout = 0
+	return stream.StreamingUser(withoutRequestTimeout(ctx))
+}
+
+// consumeNotifications reads notifications from the stream until max have
+// been seen, the context is done, or the stream ends. It returns the number of
+// notifications seen and the ID of the last one.
+func consumeNotifications(ctx context.Context, events <-chan mastodon.Event, max int) (int, mastodon.ID, error) {
+	seen := 0
+	var lastID mastodon.ID
+	for seen < max {
+		select {
+		case <-ctx.Done():
+			return seen, lastID, nil
+		case event, ok := <-events:
+			if !ok {
+				return seen, lastID, nil
+			}
+			switch e := event.(type) {
+			case *mastodon.NotificationEvent:
+				seen++
+				lastID = e.Notification.ID
+			case *mastodon.ErrorEvent:
+				// Reconnect attempts fail once the context is done.
+				if ctx.Err() != nil {
+					return seen, lastID, nil
+				}
+				return seen, lastID, e.Err
+			}
+		}
+	}
+	return seen, lastID, nil
+}
+
+// advanceNotificationsMarker moves the notifications read marker to the
+// given notification.
+func (c *MastodonClient) advanceNotificationsMarker(ctx context.Context, lastReadID mastodon.ID) error {
+	// The mastodon library has no support for markers, so the endpoint is
+	// called directly.
+	params := url.Values{"notifications[last_read_id]": {string(lastReadID)}}
+	return c.doAPI(ctx, http.MethodPost, "/api/v1/markers", params, nil)
+}
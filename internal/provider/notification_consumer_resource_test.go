@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccNotificationConsumerResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mastodon_notification_consumer" "test" {
+  timeout_seconds = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mastodon_notification_consumer.test", "id"),
+					resource.TestCheckResourceAttrSet("mastodon_notification_consumer.test", "events_seen"),
+				),
+			},
+		},
+	})
+}
+
+func TestConsumeNotifications_MaxEvents(t *testing.T) {
+	events := make(chan mastodon.Event, 4)
+	events <- &mastodon.UpdateEvent{Status: &mastodon.Status{ID: "1"}}
+	events <- &mastodon.NotificationEvent{Notification: &mastodon.Notification{ID: "10"}}
+	events <- &mastodon.NotificationEvent{Notification: &mastodon.Notification{ID: "11"}}
+	events <- &mastodon.NotificationEvent{Notification: &mastodon.Notification{ID: "12"}}
+
+	seen, lastID, err := consumeNotifications(context.Background(), events, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, seen)
+	assert.Equal(t, mastodon.ID("11"), lastID)
+}
+
+func TestConsumeNotifications_Timeout(t *testing.T) {
+	events := make(chan mastodon.Event, 1)
+	events <- &mastodon.NotificationEvent{Notification: &mastodon.Notification{ID: "10"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	seen, lastID, err := consumeNotifications(ctx, events, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, seen)
+	assert.Equal(t, mastodon.ID("10"), lastID)
+}
+
+func TestConsumeNotifications_StreamError(t *testing.T) {
+	events := make(chan mastodon.Event, 1)
+	events <- &mastodon.ErrorEvent{Err: errors.New("bad request: 404 Not Found")}
+
+	_, _, err := consumeNotifications(context.Background(), events, 20)
+	assert.EqualError(t, err, "bad request: 404 Not Found")
+}
+
+func TestAdvanceNotificationsMarker(t *testing.T) {
+	var lastReadID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/markers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = r.ParseForm()
+		lastReadID = r.PostForm.Get("notifications[last_read_id]")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"notifications":{"last_read_id":"12","version":2}}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	assert.NoError(t, client.advanceNotificationsMarker(context.Background(), "12"))
+	assert.Equal(t, "12", lastReadID)
+}
+
+func TestStreamingUser_OutlivesRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		// The notification arrives after the request timeout has passed.
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("event: notification\ndata: {\"id\":\"10\",\"type\":\"mention\"}\n\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	client.Timeout = 50 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	events, err := client.streamingUser(ctx)
+	assert.NoError(t, err)
+	seen, lastID, err := consumeNotifications(ctx, events, 1)
+	cancel()
+	for range events {
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, seen)
+	assert.Equal(t, mastodon.ID("10"), lastID)
+	assert.Equal(t, 50*time.Millisecond, client.Timeout, "the provider's client keeps its timeout")
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// notificationTypes are the types of notification the server sends.
+var notificationTypes = []string{
+	"mention", "status", "reblog", "follow", "follow_request", "favourite", "poll", "update", "admin.sign_up", "admin.report",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NotificationsDataSource{}
+
+func NewNotificationsDataSource() datasource.DataSource {
+	return &NotificationsDataSource{}
+}
+
+// NotificationsDataSource defines the data source implementation.
+type NotificationsDataSource struct {
+	client *MastodonClient
+}
+
+// NotificationsDataSourceModel describes the data source data model.
+type NotificationsDataSourceModel struct {
+	Types         types.Set           `tfsdk:"types"`
+	ExcludeTypes  types.Set           `tfsdk:"exclude_types"`
+	Limit         types.Int64         `tfsdk:"limit"`
+	MaxId         types.String        `tfsdk:"max_id"`
+	Notifications []NotificationModel `tfsdk:"notifications"`
+	SnapshotMaxId types.String        `tfsdk:"snapshot_max_id"`
+}
+
+// NotificationModel describes a single notification.
+type NotificationModel struct {
+	Id        types.String `tfsdk:"id"`
+	Type      types.String `tfsdk:"type"`
+	AccountId types.String `tfsdk:"account_id"`
+	StatusId  types.String `tfsdk:"status_id"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+func (d *NotificationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notifications"
+}
+
+func (d *NotificationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	typeValidators := []validator.Set{
+		setvalidator.ValueStringsAre(stringvalidator.OneOf(notificationTypes...)),
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads the most recent notifications of the authenticated account, e.g. to react to new mentions. " +
+			"Reading them does not dismiss them. " +
+			"New notifications arrive all the time, so each read may return different notifications. " +
+			"To read the same notifications again, set `max_id` to the `snapshot_max_id` of an earlier read.",
+
+		Attributes: map[string]schema.Attribute{
+			"types": schema.SetAttribute{
+				MarkdownDescription: "Only return notifications of these types, e.g. `mention`, `favourite`, `reblog` or `follow`. Returns every type when not set.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          typeValidators,
+			},
+			"exclude_types": schema.SetAttribute{
+				MarkdownDescription: "Leave out notifications of these types.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          typeValidators,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of notifications to return, up to 80. Defaults to the server's default of 40.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 80),
+				},
+			},
+			"max_id": schema.StringAttribute{
+				MarkdownDescription: "Only return notifications older than this ID, e.g. the `snapshot_max_id` of an earlier read.",
+				Optional:            true,
+			},
+			"snapshot_max_id": schema.StringAttribute{
+				MarkdownDescription: "The `max_id` that reads the same notifications again. This is `max_id` when it is set. " +
+					"Otherwise it is an ID just above the newest notification returned, or that notification's ID on servers without numeric IDs, such as Pleroma. " +
+					"Null when no notifications were returned.",
+				Computed: true,
+			},
+			"notifications": schema.ListNestedAttribute{
+				MarkdownDescription: "The notifications, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the notification.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the notification, e.g. `mention`.",
+							Computed:            true,
+						},
+						"account_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the account that caused the notification.",
+							Computed:            true,
+						},
+						"status_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the status the notification is about. Null for notifications without one, such as `follow`.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "When the notification was sent, as an RFC 3339 timestamp.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NotificationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NotificationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NotificationsDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_notifications data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var includeTypes, excludeTypes []string
+	resp.Diagnostics.Append(data.Types.ElementsAs(ctx, &includeTypes, false)...)
+	resp.Diagnostics.Append(data.ExcludeTypes.ElementsAs(ctx, &excludeTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notifications, err := d.client.notifications(ctx, includeTypes, excludeTypes, data.Limit.ValueInt64(), data.MaxId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read notifications",
+			fmt.Sprintf("Failed to read notifications: %s", err),
+		)
+		return
+	}
+
+	data.setNotifications(notifications)
+
+	newest := ""
+	if len(notifications) > 0 {
+		newest = string(notifications[0].ID)
+	}
+	data.SnapshotMaxId = snapshotMaxIDValue(data.MaxId, newest)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_notifications data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setNotifications maps the notifications onto the model.
+func (data *NotificationsDataSourceModel) setNotifications(notifications []*mastodon.Notification) {
+	data.Notifications = make([]NotificationModel, 0, len(notifications))
+	for _, notification := range notifications {
+		statusId := types.StringNull()
+		if notification.Status != nil {
+			statusId = types.StringValue(string(notification.Status.ID))
+		}
+		data.Notifications = append(data.Notifications, NotificationModel{
+			Id:        types.StringValue(string(notification.ID)),
+			Type:      types.StringValue(notification.Type),
+			AccountId: types.StringValue(string(notification.Account.ID)),
+			StatusId:  statusId,
+			CreatedAt: timeValueOrNull(notification.CreatedAt),
+		})
+	}
+}
+
+// notifications returns the most recent notifications older than maxID. The
+// mastodon library cannot filter them by type, so the endpoint is called
+// directly. A limit of zero leaves the number to the server, and an empty
+// maxID starts at the newest notification.
+func (c *MastodonClient) notifications(ctx context.Context, includeTypes []string, excludeTypes []string, limit int64, maxID string) ([]*mastodon.Notification, error) {
+	params := url.Values{}
+	for _, t := range includeTypes {
+		params.Add("types[]", t)
+	}
+	for _, t := range excludeTypes {
+		params.Add("exclude_types[]", t)
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+	if maxID != "" {
+		params.Set("max_id", maxID)
+	}
+
+	var notifications []*mastodon.Notification
+	if err := c.doAPI(ctx, http.MethodGet, "/api/v1/notifications", params, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccNotificationsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccNotificationsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_notifications.test", "notifications.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccNotificationsDataSourceConfig = `
+data "mastodon_notifications" "test" {
+  types = ["mention"]
+  limit = 5
+}
+`
+
+func TestNotifications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/notifications", r.URL.Path)
+		assert.Equal(t, []string{"mention", "follow"}, r.URL.Query()["types[]"])
+		assert.Equal(t, []string{"favourite"}, r.URL.Query()["exclude_types[]"])
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "35", r.URL.Query().Get("max_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id":"34","type":"mention","created_at":"2024-05-06T10:00:00.000Z","account":{"id":"7"},"status":{"id":"109372843234"}},
+			{"id":"33","type":"follow","created_at":"2024-05-06T09:00:00.000Z","account":{"id":"8"},"status":null}
+		]`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	notifications, err := client.notifications(context.Background(), []string{"mention", "follow"}, []string{"favourite"}, 10, "35")
+	assert.NoError(t, err)
+
+	var data NotificationsDataSourceModel
+	data.setNotifications(notifications)
+	assert.Equal(t, []NotificationModel{
+		{
+			Id:        types.StringValue("34"),
+			Type:      types.StringValue("mention"),
+			AccountId: types.StringValue("7"),
+			StatusId:  types.StringValue("109372843234"),
+			CreatedAt: types.StringValue("2024-05-06T10:00:00Z"),
+		},
+		{
+			Id:        types.StringValue("33"),
+			Type:      types.StringValue("follow"),
+			AccountId: types.StringValue("8"),
+			StatusId:  types.StringNull(),
+			CreatedAt: types.StringValue("2024-05-06T09:00:00Z"),
+		},
+	}, data.Notifications)
+}
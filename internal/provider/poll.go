@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// defaultMinPollExpiration is the shortest poll Mastodon accepts, in seconds,
+// used when the server does not advertise a limit.
+const defaultMinPollExpiration = 300
+
+// pollAttrTypes describes the `poll` object of posts.
+var pollAttrTypes = map[string]attr.Type{
+	"options":     types.ListType{ElemType: types.StringType},
+	"expires_in":  types.Int64Type,
+	"multiple":    types.BoolType,
+	"hide_totals": types.BoolType,
+	"expires_at":  types.StringType,
+	"own_votes":   types.ListType{ElemType: types.Int64Type},
+}
+
+// PostPollModel describes the poll attached to a post.
+type PostPollModel struct {
+	Options    types.List   `tfsdk:"options"`
+	ExpiresIn  types.Int64  `tfsdk:"expires_in"`
+	Multiple   types.Bool   `tfsdk:"multiple"`
+	HideTotals types.Bool   `tfsdk:"hide_totals"`
+	ExpiresAt  types.String `tfsdk:"expires_at"`
+	OwnVotes   types.List   `tfsdk:"own_votes"`
+}
+
+// options returns the poll options, skipping those not known yet.
+func (poll *PostPollModel) options() []string {
+	var options []string
+	for _, element := range poll.Options.Elements() {
+		if option, ok := element.(types.String); ok && !option.IsUnknown() {
+			options = append(options, option.ValueString())
+		}
+	}
+	return options
+}
+
+// postPoll returns the poll configured on the post, or nil when there is
+// none.
+func (data *PostResourceModel) postPoll(ctx context.Context) (*PostPollModel, diag.Diagnostics) {
+	if data.Poll.IsNull() || data.Poll.IsUnknown() {
+		return nil, nil
+	}
+
+	var poll PostPollModel
+	diags := data.Poll.As(ctx, &poll, basetypes.ObjectAsOptions{})
+	return &poll, diags
+}
+
+// tootPoll returns the poll parameters of a new post.
+func (poll *PostPollModel) tootPoll() *mastodon.TootPoll {
+	return &mastodon.TootPoll{
+		Options:          poll.options(),
+		ExpiresInSeconds: poll.ExpiresIn.ValueInt64(),
+		Multiple:         poll.Multiple.ValueBool(),
+		HideTotals:       poll.HideTotals.ValueBool(),
+	}
+}
+
+// remainingTootPoll returns the poll parameters of an edited post. Mastodon
+// removes the poll of a post edited without one and restarts it from the
+// given duration, so the time the poll has left is sent to keep its expiry.
+func (poll *PostPollModel) remainingTootPoll(now time.Time) (*mastodon.TootPoll, error) {
+	expiresAt, err := time.Parse(time.RFC3339, poll.ExpiresAt.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the poll expiry %q: %w", poll.ExpiresAt.ValueString(), err)
+	}
+
+	toot := poll.tootPoll()
+	toot.ExpiresInSeconds = int64(math.Round(expiresAt.Sub(now).Seconds()))
+	return toot, nil
+}
+
+// pollValue maps the poll of a post to an object, null when the post has
+// none. The duration and whether totals are hidden are not returned by the
+// server, so they are kept from the current value, or derived from the post
+// when it was imported. Keeping the duration also means a poll the server
+// clamped to its limits does not differ from the configuration, while
+// `expires_at` reports when the server actually closes it.
+func pollValue(poll *mastodon.Poll, createdAt time.Time, current types.Object) types.Object {
+	if poll == nil {
+		return types.ObjectNull(pollAttrTypes)
+	}
+
+	options := make([]attr.Value, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		options = append(options, types.StringValue(option.Title))
+	}
+
+	expiresIn := types.Int64Value(int64(math.Round(poll.ExpiresAt.Sub(createdAt).Seconds())))
+	hideTotals := types.BoolValue(false)
+	if !current.IsNull() && !current.IsUnknown() {
+		if value, ok := current.Attributes()["expires_in"].(types.Int64); ok && !value.IsNull() && !value.IsUnknown() {
+			expiresIn = value
+		}
+		if value, ok := current.Attributes()["hide_totals"].(types.Bool); ok && !value.IsNull() && !value.IsUnknown() {
+			hideTotals = value
+		}
+	}
+
+	return types.ObjectValueMust(pollAttrTypes, map[string]attr.Value{
+		"options":     types.ListValueMust(types.StringType, options),
+		"expires_in":  expiresIn,
+		"multiple":    types.BoolValue(poll.Multiple),
+		"hide_totals": hideTotals,
+		"expires_at":  types.StringValue(poll.ExpiresAt.UTC().Format(time.RFC3339)),
+		"own_votes":   ownVotesValue(poll),
+	})
+}
+
+// ownVotesValue returns the indexes of the options the authenticated account
+// voted for, null before it has voted.
+func ownVotesValue(poll *mastodon.Poll) types.List {
+	if !poll.Voted || len(poll.OwnVotes) == 0 {
+		return types.ListNull(types.Int64Type)
+	}
+
+	votes := make([]attr.Value, 0, len(poll.OwnVotes))
+	for _, vote := range poll.OwnVotes {
+		votes = append(votes, types.Int64Value(int64(vote)))
+	}
+	return types.ListValueMust(types.Int64Type, votes)
+}
+
+// pollLimits returns the poll limits the server advertises. Limits the
+// server does not advertise are zero.
+func (c *MastodonClient) pollLimits(ctx context.Context) instancePolls {
+	inst, err := c.cachedInstance(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to read the poll limits, skipping poll validation", map[string]interface{}{"error": err.Error()})
+		return instancePolls{}
+	}
+	return inst.polls()
+}
+
+// checkPoll returns a description of each way the poll exceeds the limits.
+func (limits instancePolls) checkPoll(poll *PostPollModel) []string {
+	var problems []string
+	if count := len(poll.Options.Elements()); limits.MaxOptions > 0 && count > limits.MaxOptions {
+		problems = append(problems, fmt.Sprintf("the poll has %d options, but the server allows at most %d", count, limits.MaxOptions))
+	}
+	if limits.MaxCharactersPerOption > 0 {
+		for _, option := range poll.options() {
+			if length := utf8.RuneCountInString(option); length > limits.MaxCharactersPerOption {
+				problems = append(problems, fmt.Sprintf("the option %q is %d characters long, but the server allows at most %d", option, length, limits.MaxCharactersPerOption))
+			}
+		}
+	}
+	if !poll.ExpiresIn.IsUnknown() {
+		expiresIn := poll.ExpiresIn.ValueInt64()
+		if limits.MinExpiration > 0 && expiresIn < int64(limits.MinExpiration) {
+			problems = append(problems, fmt.Sprintf("the poll expires in %d seconds, but the server requires at least %d", expiresIn, limits.MinExpiration))
+		}
+		if limits.MaxExpiration > 0 && expiresIn > int64(limits.MaxExpiration) {
+			problems = append(problems, fmt.Sprintf("the poll expires in %d seconds, but the server allows at most %d", expiresIn, limits.MaxExpiration))
+		}
+	}
+	return problems
+}
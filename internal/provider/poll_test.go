@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPoll(expiresIn int64, hideTotals bool, options ...string) types.Object {
+	values := make([]attr.Value, 0, len(options))
+	for _, option := range options {
+		values = append(values, types.StringValue(option))
+	}
+	return types.ObjectValueMust(pollAttrTypes, map[string]attr.Value{
+		"options":     types.ListValueMust(types.StringType, values),
+		"expires_in":  types.Int64Value(expiresIn),
+		"multiple":    types.BoolValue(false),
+		"hide_totals": types.BoolValue(hideTotals),
+		"expires_at":  types.StringUnknown(),
+		"own_votes":   types.ListUnknown(types.Int64Type),
+	})
+}
+
+func TestPollValue(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	poll := &mastodon.Poll{
+		ExpiresAt: createdAt.Add(24*time.Hour + 300*time.Millisecond),
+		Options:   []mastodon.PollOption{{Title: "Tabs"}, {Title: "Spaces"}},
+	}
+
+	// The configured duration and hidden totals are kept.
+	value := pollValue(poll, createdAt, testPoll(86000, true, "Tabs", "Spaces"))
+	attributes := value.Attributes()
+	assert.Equal(t, types.Int64Value(86000), attributes["expires_in"])
+	assert.Equal(t, types.BoolValue(true), attributes["hide_totals"])
+	assert.Equal(t, types.StringValue("2024-05-02T12:00:00Z"), attributes["expires_at"])
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Tabs"), types.StringValue("Spaces")}), attributes["options"])
+
+	// Imported polls derive the duration from the post.
+	attributes = pollValue(poll, createdAt, types.ObjectNull(pollAttrTypes)).Attributes()
+	assert.Equal(t, types.Int64Value(86400), attributes["expires_in"])
+	assert.Equal(t, types.BoolValue(false), attributes["hide_totals"])
+
+	assert.True(t, pollValue(nil, createdAt, testPoll(86400, false, "Tabs", "Spaces")).IsNull())
+}
+
+func TestPollValue_ClampedExpiry(t *testing.T) {
+	// The poll asked for 60 days, but the server allows at most 30.
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	poll := &mastodon.Poll{
+		ExpiresAt: createdAt.Add(30 * 24 * time.Hour),
+		Options:   []mastodon.PollOption{{Title: "Tabs"}, {Title: "Spaces"}},
+	}
+
+	// The configured duration is kept so the plan has no diff, while the
+	// expiry the server returned is authoritative.
+	value := pollValue(poll, createdAt, testPoll(60*86400, false, "Tabs", "Spaces"))
+	assert.Equal(t, types.Int64Value(60*86400), value.Attributes()["expires_in"])
+	assert.Equal(t, types.StringValue("2024-05-31T12:00:00Z"), value.Attributes()["expires_at"])
+
+	// Reading it again does not change it.
+	assert.True(t, value.Equal(pollValue(poll, createdAt, value)))
+}
+
+func TestPollValue_OwnVotes(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var votes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v1/polls/3/votes" {
+			assert.NoError(t, r.ParseForm())
+			votes = r.PostForm["choices[]"]
+		}
+		voted := "false"
+		ownVotes := "[]"
+		if len(votes) > 0 {
+			voted = "true"
+			ownVotes = "[" + votes[0] + "]"
+		}
+		poll := `{"id":"3","expires_at":"2024-05-02T12:00:00Z","voted":` + voted + `,"own_votes":` + ownVotes + `,"options":[{"title":"Tabs"},{"title":"Spaces"}]}`
+		if r.URL.Path == "/api/v1/statuses/7" {
+			_, _ = w.Write([]byte(`{"id":"7","created_at":"2024-05-01T12:00:00Z","poll":` + poll + `}`))
+			return
+		}
+		_, _ = w.Write([]byte(poll))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	read := func(current types.Object) types.Object {
+		status, err := client.GetStatus(context.Background(), "7")
+		assert.NoError(t, err)
+		return pollValue(status.Poll, createdAt, current)
+	}
+
+	// own_votes is null before the account has voted.
+	value := read(testPoll(86400, false, "Tabs", "Spaces"))
+	assert.True(t, value.Attributes()["own_votes"].IsNull())
+
+	_, err := client.PollVote(context.Background(), "3", 1)
+	assert.NoError(t, err)
+
+	value = read(value)
+	assert.Equal(t, types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(1)}), value.Attributes()["own_votes"])
+
+	// Reading it again does not change it.
+	assert.True(t, value.Equal(read(value)))
+}
+
+func TestRemainingTootPoll(t *testing.T) {
+	poll := PostPollModel{
+		Options:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Tabs"), types.StringValue("Spaces")}),
+		ExpiresIn:  types.Int64Value(86400),
+		Multiple:   types.BoolValue(true),
+		HideTotals: types.BoolValue(false),
+		ExpiresAt:  types.StringValue("2024-05-02T12:00:00Z"),
+	}
+
+	toot, err := poll.remainingTootPoll(time.Date(2024, 5, 2, 11, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3600), toot.ExpiresInSeconds)
+	assert.Equal(t, []string{"Tabs", "Spaces"}, toot.Options)
+	assert.True(t, toot.Multiple)
+
+	poll.ExpiresAt = types.StringNull()
+	_, err = poll.remainingTootPoll(time.Now())
+	assert.Error(t, err)
+}
+
+func TestCheckPoll(t *testing.T) {
+	limits := instancePolls{MaxOptions: 4, MaxCharactersPerOption: 6, MinExpiration: 300, MaxExpiration: 2629746}
+
+	poll := PostPollModel{
+		Options:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Tabs"), types.StringValue("Spaces")}),
+		ExpiresIn: types.Int64Value(86400),
+	}
+	assert.Empty(t, limits.checkPoll(&poll))
+
+	// Spaces is one character too long, and the poll is too short.
+	poll.Options = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Tabs"), types.StringValue("Spaces!")})
+	poll.ExpiresIn = types.Int64Value(60)
+	assert.Len(t, limits.checkPoll(&poll), 2)
+
+	// Servers that do not advertise limits are not checked.
+	assert.Empty(t, instancePolls{}.checkPoll(&poll))
+}
@@ -2,24 +2,65 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mattn/go-mastodon"
-	"github.com/microcosm-cc/bluemonday"
 )
 
+// postVisibilities lists the visibilities a post can have, from the widest
+// audience to the narrowest.
+var postVisibilities = []string{"public", "unlisted", "private", "direct"}
+
+// defaultPostVisibility is the visibility of posts that do not set one.
+const defaultPostVisibility = "public"
+
+// What to do when a plan changes a post field the server cannot edit, set
+// by the provider's `immutable_field_policy`.
+const (
+	// immutableFieldPolicyError fails the plan.
+	immutableFieldPolicyError = "error"
+	// immutableFieldPolicyRecreate deletes the post and posts it again.
+	immutableFieldPolicyRecreate = "recreate"
+)
+
+// immutableFieldPolicies lists the values of `immutable_field_policy`.
+var immutableFieldPolicies = []string{immutableFieldPolicyError, immutableFieldPolicyRecreate}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PostResource{}
 var _ resource.ResourceWithImportState = &PostResource{}
+var _ resource.ResourceWithModifyPlan = &PostResource{}
+var _ resource.ResourceWithValidateConfig = &PostResource{}
+var _ resource.ResourceWithUpgradeState = &PostResource{}
 
 func NewPostResource() resource.Resource {
 	return &PostResource{}
@@ -27,18 +68,227 @@ func NewPostResource() resource.Resource {
 
 // PostResource defines the resource implementation.
 type PostResource struct {
-	client *mastodon.Client
+	client *MastodonClient
 }
 
 // PostResourceModel describes the resource data model.
 type PostResourceModel struct {
 	Id                types.String `tfsdk:"id"`
 	CreatedAt         types.String `tfsdk:"created_at"`
+	EditedAt          types.String `tfsdk:"edited_at"`
 	Account           types.String `tfsdk:"account"`
+	Uri               types.String `tfsdk:"uri"`
+	Url               types.String `tfsdk:"url"`
 	Content           types.String `tfsdk:"content"`
+	ContentHtml       types.String `tfsdk:"content_html"`
+	ContentType       types.String `tfsdk:"content_type"`
 	Visibility        types.String `tfsdk:"visibility"`
 	Sensitive         types.Bool   `tfsdk:"sensitive"`
+	SpoilerText       types.String `tfsdk:"spoiler_text"`
+	Language          types.String `tfsdk:"language"`
+	InReplyToId       types.String `tfsdk:"in_reply_to_id"`
+	AutoMentionParent types.Bool   `tfsdk:"auto_mention_parent"`
+	InheritVisibility types.Bool   `tfsdk:"inherit_parent_visibility"`
+	Attachments       types.List   `tfsdk:"attachments"`
+	MediaIds          types.List   `tfsdk:"media_ids"`
+	Poll              types.Object `tfsdk:"poll"`
+	ScheduledAt       types.String `tfsdk:"scheduled_at"`
+	Scheduled         types.Bool   `tfsdk:"scheduled"`
+	Bookmarked        types.Bool   `tfsdk:"bookmarked"`
+	Favourited        types.Bool   `tfsdk:"favourited"`
+	Reblogged         types.Bool   `tfsdk:"reblogged"`
+	Pinned            types.Bool   `tfsdk:"pinned"`
+	Card              types.Object `tfsdk:"card"`
+	Mentions          types.List   `tfsdk:"mentions"`
+	Tags              types.List   `tfsdk:"tags"`
 	PreserveOnDestroy types.Bool   `tfsdk:"preserve_on_destroy"`
+	AccessToken       types.String `tfsdk:"access_token"`
+	IdempotencyKey    types.String `tfsdk:"idempotency_key"`
+}
+
+// setStatus updates the model with the post data returned by the server.
+func (data *PostResourceModel) setStatus(post *mastodon.Status) {
+	data.Id = types.StringValue(string(post.ID))
+	data.Scheduled = types.BoolValue(false)
+	data.CreatedAt = types.StringValue(post.CreatedAt.Format(time.RFC3339))
+	data.EditedAt = timeValueOrNull(post.EditedAt)
+	data.Account = types.StringValue(string(post.Account.ID))
+	data.Uri = types.StringValue(post.URI)
+	data.Url = stringValueOrNull(post.URL)
+	data.Content = types.StringValue(statusText(post.Content))
+	data.ContentHtml = types.StringValue(post.Content)
+	data.Visibility = types.StringValue(post.Visibility)
+	data.Sensitive = types.BoolValue(post.Sensitive)
+	data.SpoilerText = types.StringValue(post.SpoilerText)
+	data.Language = stringValueOrNull(post.Language)
+	data.InReplyToId = idValueOrNull(post.InReplyToID)
+	data.Bookmarked = boolValueOrFalse(post.Bookmarked)
+	data.Favourited = boolValueOrFalse(post.Favourited)
+	data.Reblogged = boolValueOrFalse(post.Reblogged)
+	data.Pinned = boolValueOrFalse(post.Pinned)
+	data.Card = cardValue(post.Card)
+	data.Mentions = mentionsValue(post.Mentions)
+	data.Tags = tagsValue(post.Tags)
+	data.MediaIds = mediaIDsValue(post.MediaAttachments, data.MediaIds)
+	data.Poll = pollValue(post.Poll, post.CreatedAt, data.Poll)
+}
+
+// mediaIDsValue returns the IDs of the post's media attachments, in order. A
+// post without media keeps the current value when it is null or empty, so
+// configuring `media_ids = []` and omitting it both plan cleanly.
+func mediaIDsValue(attachments []mastodon.Attachment, current types.List) types.List {
+	if len(attachments) == 0 {
+		if current.IsUnknown() {
+			return types.ListNull(types.StringType)
+		}
+		if len(current.Elements()) == 0 {
+			return current
+		}
+	}
+
+	ids := make([]attr.Value, 0, len(attachments))
+	for _, attachment := range attachments {
+		ids = append(ids, types.StringValue(string(attachment.ID)))
+	}
+	return types.ListValueMust(types.StringType, ids)
+}
+
+// mediaIDs returns the media IDs configured for the post.
+func (data *PostResourceModel) mediaIDs(ctx context.Context) ([]mastodon.ID, diag.Diagnostics) {
+	if data.MediaIds.IsNull() || data.MediaIds.IsUnknown() {
+		return nil, nil
+	}
+
+	var ids []string
+	diags := data.MediaIds.ElementsAs(ctx, &ids, false)
+	mediaIDs := make([]mastodon.ID, 0, len(ids))
+	for _, id := range ids {
+		mediaIDs = append(mediaIDs, mastodon.ID(id))
+	}
+	return mediaIDs, diags
+}
+
+// idempotencyKey derives the key a post is created with from the account
+// posting it and the attributes that make up the post. An apply that is run
+// again after a create failed or timed out therefore sends the same key, and
+// the server answers it with the post it already created. It reports false
+// while any of those attributes is not known yet.
+func (data *PostResourceModel) idempotencyKey(accountID mastodon.ID) (string, bool) {
+	values := []attr.Value{data.Content, data.SpoilerText, data.Visibility, data.InReplyToId, data.ScheduledAt, data.Attachments}
+	// The IDs of uploaded attachments are only known once they are uploaded,
+	// so they are covered by the attachments themselves.
+	if data.Attachments.IsNull() {
+		values = append(values, data.MediaIds)
+	}
+	// The poll's computed attributes are left out.
+	if data.Poll.IsUnknown() {
+		return "", false
+	}
+	if !data.Poll.IsNull() {
+		poll := data.Poll.Attributes()
+		values = append(values, poll["options"], poll["expires_in"], poll["multiple"], poll["hide_totals"])
+	}
+
+	h := sha256.New()
+	fmt.Fprintln(h, accountID)
+	for _, value := range values {
+		if value.IsUnknown() {
+			return "", false
+		}
+		fmt.Fprintln(h, value.String())
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32], true
+}
+
+// keepEquivalentContent keeps the configured content in state when the
+// content on the server only differs from it in what is lost rendering it to
+// HTML and back, so the round trip does not show up as drift.
+func (data *PostResourceModel) keepEquivalentContent(content types.String) {
+	if content.IsNull() || content.IsUnknown() {
+		return
+	}
+	if normalizeStatusText(data.Content.ValueString()) == normalizeStatusText(content.ValueString()) {
+		data.Content = content
+	}
+}
+
+// keepContentWithoutParentMention keeps the configured content in state when
+// the content on the server only differs by the mention auto_mention_parent
+// prepended, so the added mention does not show up as drift.
+func (data *PostResourceModel) keepContentWithoutParentMention(content types.String) {
+	if !data.AutoMentionParent.ValueBool() || content.IsNull() || content.IsUnknown() {
+		return
+	}
+	if withoutLeadingMentions(normalizeStatusText(data.Content.ValueString())) == withoutLeadingMentions(normalizeStatusText(content.ValueString())) {
+		data.Content = content
+	}
+}
+
+// keepTruncatedContent keeps the configured content in state when the content
+// on the server is a prefix of it followed by the truncation suffix, so
+// truncate_over_limit does not show up as drift.
+func (data *PostResourceModel) keepTruncatedContent(content types.String, suffix string) {
+	if content.IsNull() || content.IsUnknown() || suffix == "" {
+		return
+	}
+	truncated, ok := strings.CutSuffix(data.Content.ValueString(), suffix)
+	if !ok {
+		return
+	}
+	if strings.HasPrefix(content.ValueString(), truncated) ||
+		strings.HasPrefix(withoutLeadingMentions(content.ValueString()), withoutLeadingMentions(truncated)) {
+		data.Content = content
+	}
+}
+
+// hasFormattedContent reports whether the content is written in a format the
+// server renders, rather than plain text.
+func (data *PostResourceModel) hasFormattedContent() bool {
+	return !data.ContentType.IsNull() && data.ContentType.ValueString() != contentTypePlain
+}
+
+// keepFormattedContent keeps the configured content in state for formatted
+// posts, as the server only returns the rendered HTML.
+func (data *PostResourceModel) keepFormattedContent(content types.String) {
+	if data.hasFormattedContent() {
+		data.Content = content
+	}
+}
+
+// setValidateOnly fills in the computed attributes of a post that was never
+// created because the provider runs in validate only mode.
+func (data *PostResourceModel) setValidateOnly() {
+	data.Id = types.StringValue(validateOnlyID)
+	if data.Scheduled.IsUnknown() {
+		data.Scheduled = types.BoolValue(!data.ScheduledAt.IsNull())
+	}
+	data.CreatedAt = types.StringNull()
+	data.EditedAt = types.StringNull()
+	data.Account = types.StringNull()
+	data.Uri = types.StringNull()
+	data.Url = types.StringNull()
+	if data.Language.IsUnknown() {
+		data.Language = types.StringNull()
+	}
+	if data.Visibility.IsUnknown() {
+		data.Visibility = types.StringNull()
+	}
+	data.Bookmarked = types.BoolValue(false)
+	data.Favourited = types.BoolValue(false)
+	data.Reblogged = types.BoolValue(false)
+	data.ContentHtml = types.StringNull()
+	data.Card = types.ObjectNull(cardAttrTypes)
+	data.Mentions = types.ListNull(types.ObjectType{AttrTypes: mentionAttrTypes})
+	data.Tags = types.ListNull(types.ObjectType{AttrTypes: tagAttrTypes})
+	if data.MediaIds.IsUnknown() {
+		data.MediaIds = types.ListNull(types.StringType)
+	}
+	if !data.Poll.IsNull() && !data.Poll.IsUnknown() {
+		poll := data.Poll.Attributes()
+		poll["expires_at"] = types.StringNull()
+		poll["own_votes"] = types.ListNull(types.Int64Type)
+		data.Poll = types.ObjectValueMust(pollAttrTypes, poll)
+	}
 }
 
 func (r *PostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,6 +300,9 @@ func (r *PostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "This resource is used to manage posts on a Mastodon instance.",
 
+		// Version 1 stores created_at as an RFC 3339 timestamp.
+		Version: 1,
+
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -61,7 +314,7 @@ func (r *PostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"created_at": schema.StringAttribute{
-				MarkdownDescription: "Timestamp of when the post was created.",
+				MarkdownDescription: "Timestamp of when the post was created, in RFC 3339 format.",
 				Computed:            true,
 				Required:            false,
 				Optional:            false,
@@ -69,6 +322,11 @@ func (r *PostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"edited_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of when the post was last edited, in RFC 3339 format. Null when the post was never edited. " +
+					"Changes when the post is edited, including outside of Terraform.",
+				Computed: true,
+			},
 			"account": schema.StringAttribute{
 				MarkdownDescription: "Account that created the post",
 				Computed:            true,
@@ -78,21 +336,312 @@ func (r *PostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"uri": schema.StringAttribute{
+				MarkdownDescription: "The ActivityPub identifier of the post, used by other servers to refer to it.",
+				Computed:            true,
+				Required:            false,
+				Optional:            false,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The public URL of the post's web page, for sharing links.",
+				Computed:            true,
+				Required:            false,
+				Optional:            false,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"content": schema.StringAttribute{
 				MarkdownDescription: "The content of the post.",
 				Required:            true,
 			},
+			"content_html": schema.StringAttribute{
+				MarkdownDescription: "The content of the post as rendered to HTML by the server, with links, mentions and hashtags marked up.",
+				Computed:            true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "The format the content is written in: can be `text/plain`, `text/markdown`, or `text/html`. " +
+					"Only forks such as Pleroma, Akkoma and glitch-soc accept formats other than plain text; see the `content_types` attribute of the `mastodon_instance` data source. " +
+					"When omitted, the server's default is used.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(postContentTypes...),
+				},
+			},
 			"visibility": schema.StringAttribute{
-				MarkdownDescription: "The post visibility: can be `public`, `unlisted`, `private`, or `direct`.",
+				MarkdownDescription: "The post visibility: can be `public`, `unlisted`, `private`, or `direct`. " +
+					"Mastodon cannot change the visibility of an existing post, so changing it follows the provider's `immutable_field_policy`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultPostVisibility),
+				Validators: []validator.String{
+					stringvalidator.OneOf(postVisibilities...),
+				},
+			},
+			"sensitive": schema.BoolAttribute{
+				MarkdownDescription: "Whether the post contains sensitive content. Defaults to `true` when the post has a content warning, as Mastodon always marks those sensitive, " +
+					"otherwise to `false` or to the provider's `default_sensitive_by_visibility` entry for the post's visibility.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"spoiler_text": schema.StringAttribute{
+				MarkdownDescription: "Content warning shown in place of the post content until it is expanded. " +
+					"When omitted, a content warning may be applied from the provider's `auto_cw_keywords`. " +
+					"A post with a content warning is always sensitive, so `sensitive` is set to `true` automatically unless it is configured.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"language": schema.StringAttribute{
+				MarkdownDescription: "ISO 639 language code of the post, such as `en` or `de`. When omitted, the language detected by the server is stored.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("public"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(languageCodePattern, "must be a lowercase ISO 639 language code such as en or de"),
+				},
 			},
-			"sensitive": schema.BoolAttribute{
-				MarkdownDescription: "Whether the post contains sensitive content.",
+			"pinned": schema.BoolAttribute{
+				MarkdownDescription: "Whether the post is pinned to the top of the account's profile. Mastodon limits the number of pinned posts, usually to 5, " +
+					"and does not pin direct posts. Cannot be set on scheduled posts. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"bookmarked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account has bookmarked the post.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"favourited": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account has favourited the post.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"reblogged": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account has boosted the post.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"in_reply_to_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the post this post replies to. Changing it creates a new post.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auto_mention_parent": schema.BoolAttribute{
+				MarkdownDescription: "When `in_reply_to_id` is set, prepend a mention of the parent post's author to the content if it does not mention them already, like Mastodon clients do. " +
+					"The added mention is not reflected in `content`. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"inherit_parent_visibility": schema.BoolAttribute{
+				MarkdownDescription: "When `in_reply_to_id` is set and `visibility` is omitted, use the visibility of the parent post, so a reply never broadens who can see a thread. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"attachments": schema.ListNestedAttribute{
+				MarkdownDescription: "Media files to upload and attach to the post, in the order they are displayed. " +
+					"The files are uploaded when the post is created, and uploaded files are deleted again if the post cannot be created. " +
+					"At most as many attachments as the instance's `max_media_attachments` setting allows, usually 4, can be set. " +
+					"Mastodon cannot change the media of an existing post, so changing the attachments follows the provider's `immutable_field_policy`.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"file_path": schema.StringAttribute{
+							MarkdownDescription: "Path to the media file to upload.",
+							Required:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Alt text describing the media for people who cannot see it.",
+							Optional:            true,
+						},
+						"focus": schema.StringAttribute{
+							MarkdownDescription: "Focal point of the image used when cropping previews, as `x,y` coordinates between `-1.0` and `1.0`, e.g. `0.0,0.5`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(focusPattern, "must be two coordinates between -1.0 and 1.0 separated by a comma"),
+							},
+						},
+					},
+				},
+			},
+			"media_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of already uploaded media to attach to the post, in the order they are displayed, e.g. from a separate media resource. " +
+					"Changing them edits the media of the post. Cannot be combined with `attachments`; when `attachments` is used, the IDs of the uploaded files are stored here. " +
+					"An empty list and an omitted value are treated the same.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("attachments")),
+				},
+			},
+			"poll": schema.SingleNestedAttribute{
+				MarkdownDescription: "A poll attached to the post. Mastodon polls cannot be changed once posted, so adding, removing, or changing the poll creates a new post. " +
+					"A poll cannot be combined with `attachments` or `media_ids`, and must stay within the `configuration.polls` limits of the `mastodon_instance` data source.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"Adding or removing the poll creates a new post.",
+						"Adding or removing the poll creates a new post.",
+					),
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("attachments"), path.MatchRoot("media_ids")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"options": schema.ListAttribute{
+						MarkdownDescription: "The choices of the poll, in the order they are displayed. At least two are required.",
+						ElementType:         types.StringType,
+						Required:            true,
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.RequiresReplace(),
+						},
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(2),
+							listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+					"expires_in": schema.Int64Attribute{
+						MarkdownDescription: "How long the poll stays open, in seconds. The `provider::mastodon::duration_seconds` function converts durations such as `3d` into seconds.",
+						Required:            true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+					"multiple": schema.BoolAttribute{
+						MarkdownDescription: "Whether more than one option can be chosen. Defaults to `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.RequiresReplace(),
+						},
+					},
+					"hide_totals": schema.BoolAttribute{
+						MarkdownDescription: "Whether vote counts are hidden until the poll closes. Defaults to `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.RequiresReplace(),
+						},
+					},
+					"expires_at": schema.StringAttribute{
+						MarkdownDescription: "When the poll closes, as an RFC 3339 timestamp. Servers may clamp `expires_in` to their limits, so this is when the server actually closes the poll.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"own_votes": schema.ListAttribute{
+						MarkdownDescription: "The indexes of the options the authenticated account voted for, starting at `0`. Null until it has voted.",
+						ElementType:         types.Int64Type,
+						Computed:            true,
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"scheduled_at": schema.StringAttribute{
+				MarkdownDescription: "When to publish the post, as an RFC 3339 timestamp at least five minutes in the future, e.g. `2024-05-01T12:00:00Z`. " +
+					"Until then the post is scheduled: it has no URL or creation time, and any change other than to `scheduled_at` schedules a new post instead. " +
+					"Once published, the post is managed like any other and changing `scheduled_at` has no effect.",
+				Optional: true,
+			},
+			"scheduled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the post is scheduled and not published yet. `id` is the ID of the scheduled post until it is published.",
 				Computed:            true,
-				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"card": schema.SingleNestedAttribute{
+				MarkdownDescription: "The preview card the server generated for the first link in the post. " +
+					"Null when the post has no link, or until the server has fetched the preview, which may happen after the post is created.",
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the linked page.",
+						Computed:            true,
+					},
+					"title": schema.StringAttribute{
+						MarkdownDescription: "The title of the linked page.",
+						Computed:            true,
+					},
+					"description": schema.StringAttribute{
+						MarkdownDescription: "The description of the linked page.",
+						Computed:            true,
+					},
+					"image": schema.StringAttribute{
+						MarkdownDescription: "URL of the preview image. Null when the page has none.",
+						Computed:            true,
+					},
+					"provider_name": schema.StringAttribute{
+						MarkdownDescription: "The name of the site that published the page. Null when unknown.",
+						Computed:            true,
+					},
+				},
+			},
+			"mentions": schema.ListNestedAttribute{
+				MarkdownDescription: "The accounts mentioned in the post, in the order the server returns them. Empty when the post mentions no one.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the mentioned account.",
+							Computed:            true,
+						},
+						"acct": schema.StringAttribute{
+							MarkdownDescription: "The handle of the mentioned account: the username for local accounts, `username@domain` for remote ones.",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The profile URL of the mentioned account.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"tags": schema.ListNestedAttribute{
+				MarkdownDescription: "The hashtags used in the post, in the order the server returns them. Empty when the post uses none.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the hashtag, without the leading `#`.",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The URL of the hashtag's timeline on the instance.",
+							Computed:            true,
+						},
+					},
+				},
 			},
 			"preserve_on_destroy": schema.BoolAttribute{
 				MarkdownDescription: "When destroyed, preserve the post on the server.",
@@ -100,6 +649,26 @@ func (r *PostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"idempotency_key": schema.StringAttribute{
+				MarkdownDescription: "Key sent with the request that creates the post, so a request that is retried, or an apply that is run again after a create failed or timed out, cannot post it twice. " +
+					"Defaults to a hash of the account and the post's content, content warning, visibility, media, poll, reply and scheduled time, so it only changes when the post does. " +
+					"The server answers a key it has seen in the last hour with the post created for it, so identical posts created within an hour of each other, e.g. with `count`, need a different key each.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token of another account on the same instance to manage the post as, instead of the provider's account. " +
+					"The provider's server and application credentials are still used. Changing it creates a new post. " +
+					"Posts managed with an overriding token cannot be imported.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -110,12 +679,12 @@ func (r *PostResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*mastodon.Client)
+	client, ok := req.ProviderData.(*MastodonClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *mastodon.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -134,28 +703,160 @@ func (r *PostResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	r = r.withAccessToken(ctx, data.AccessToken, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	toot := mastodon.Toot{
-		Status:     data.Content.ValueString(),
-		Visibility: data.Visibility.ValueString(),
-		Sensitive:  data.Sensitive.ValueBool(),
+		Status:      data.Content.ValueString(),
+		InReplyToID: mastodon.ID(data.InReplyToId.ValueString()),
+		Visibility:  data.Visibility.ValueString(),
+		Sensitive:   data.Sensitive.ValueBool(),
+		SpoilerText: data.SpoilerText.ValueString(),
+		Language:    data.Language.ValueString(),
+	}
+
+	scheduledAt, err := data.scheduledAt()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scheduled_at"), "Invalid Scheduled Time", err.Error())
+		return
+	}
+	toot.ScheduledAt = scheduledAt
+
+	// The key is derived when planning, unless the post depended on values
+	// that were only known once applying.
+	if data.IdempotencyKey.IsUnknown() || data.IdempotencyKey.IsNull() {
+		key, ok := data.idempotencyKey(r.client.currentAccountID())
+		if !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("idempotency_key"), "Unable to Derive Idempotency Key", "The post still has unknown values. Please report this issue to the provider developers.")
+			return
+		}
+		data.IdempotencyKey = types.StringValue(key)
+	}
+
+	if r.client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping post creation.")
+		data.setValidateOnly()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
 
-	post, err := r.client.PostStatus(context.Background(), &toot)
+	if r.client.resolveMentions {
+		r.warnUnresolvedMentions(ctx, data.Content.ValueString(), &resp.Diagnostics)
+	}
+
+	// The parent was not known when planning, so its visibility is
+	// inherited now.
+	if data.Visibility.IsUnknown() {
+		visibility, err := r.parentVisibility(ctx, data.InReplyToId.ValueString(), defaultPostVisibility)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parent post, got error: %s", err))
+			return
+		}
+		toot.Visibility = visibility
+	}
+
+	if data.AutoMentionParent.ValueBool() && !data.InReplyToId.IsNull() {
+		status, err := r.mentionParent(ctx, data.InReplyToId.ValueString(), toot.Status)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parent post, got error: %s", err))
+			return
+		}
+		toot.Status = status
+	}
+
+	if status, truncated := r.client.truncateContent(ctx, toot.Status, toot.SpoilerText); truncated {
+		tflog.Debug(ctx, "truncating post content over the server's limit")
+		toot.Status = status
+	}
+
+	mediaIDs, diags := data.mediaIDs(ctx)
+	resp.Diagnostics.Append(diags...)
+	toot.MediaIDs = mediaIDs
+
+	if r.client.mediaCache != nil && len(mediaIDs) > 0 {
+		if id, ok := r.client.mediaCache.claim(mediaIDs); !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("media_ids"),
+				"Media Already Attached",
+				fmt.Sprintf("Media %s is attached to another post created in this run. "+
+					"deduplicate_media gives identical mastodon_media the same ID, but Mastodon attaches media to a single post. "+
+					"Change the description or focus of one of them, or disable deduplicate_media.", id),
+			)
+			return
+		}
+	}
+
+	poll, diags := data.postPoll(ctx)
+	resp.Diagnostics.Append(diags...)
+	if poll != nil {
+		toot.Poll = poll.tootPoll()
+	}
+
+	var attachments []PostAttachmentModel
+	resp.Diagnostics.Append(data.Attachments.ElementsAs(ctx, &attachments, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var uploaded []mastodon.ID
+	if len(attachments) > 0 {
+		var err error
+		uploaded, err = r.client.uploadAttachments(ctx, attachments)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload attachments, got error: %s", err))
+			return
+		}
+		toot.MediaIDs = uploaded
+	}
+
+	if toot.ScheduledAt != nil {
+		scheduled, err := r.client.scheduleStatus(ctx, &toot, data.ContentType.ValueString(), data.IdempotencyKey.ValueString())
+		if err != nil {
+			r.client.deleteMedia(ctx, uploaded)
+			r.client.releaseMedia(mediaIDs)
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to schedule post, got error: %s", err))
+			return
+		}
+
+		data.setScheduledStatus(scheduled, r.client.currentAccountID())
+		tflog.Trace(ctx, "scheduled a post")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	post, err := r.client.postStatus(ctx, &toot, data.ContentType.ValueString(), data.IdempotencyKey.ValueString())
 
 	if err != nil {
+		// Media referenced by media_ids is managed elsewhere and kept.
+		r.client.deleteMedia(ctx, uploaded)
+		r.client.releaseMedia(mediaIDs)
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create post, got error: %s", err))
 		return
 	}
 
-	p := bluemonday.NewPolicy()
-
 	// Update the model with the created post data
-	data.Id = types.StringValue(string(post.ID))
-	data.CreatedAt = types.StringValue(post.CreatedAt.String())
-	data.Account = types.StringValue(string(post.Account.ID))
-	data.Content = types.StringValue(p.Sanitize(post.Content))
-	data.Visibility = types.StringValue(post.Visibility)
-	data.Sensitive = types.BoolValue(post.Sensitive)
+	content := data.Content
+	pinned := data.Pinned
+	data.setStatus(post)
+	data.keepEquivalentContent(content)
+	data.keepContentWithoutParentMention(content)
+	data.keepFormattedContent(content)
+	if r.client.truncateOverLimit {
+		data.keepTruncatedContent(content, r.client.truncationSuffix)
+	}
+
+	if pinned.ValueBool() {
+		if err := r.client.setPinned(ctx, post.ID, true); err != nil {
+			// The post was created, so it is saved, unpinned, to keep
+			// Terraform tracking it.
+			resp.Diagnostics.AddAttributeError(path.Root("pinned"), "Unable to Pin Post", pinErrorDetail(string(post.ID), err))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+		data.Pinned = pinned
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -175,26 +876,81 @@ func (r *PostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	post, err := r.client.GetStatus(context.Background(), mastodon.ID(data.Id.ValueString()))
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "post was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	r = r.withAccessToken(ctx, data.AccessToken, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Scheduled.ValueBool() {
+		scheduled, err := r.client.getScheduledStatus(ctx, data.Id.ValueString())
+		if err == nil {
+			data.setScheduledStatus(scheduled, r.client.currentAccountID())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+		if !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scheduled post, got error: %s", err))
+			return
+		}
+
+		// The post was published under a new ID, or cancelled outside of
+		// Terraform.
+		published, err := r.publishedStatus(ctx, &data)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find published post, got error: %s", err))
+			return
+		}
+		if published == nil {
+			tflog.Debug(ctx, "scheduled post was cancelled: removing it from state.", map[string]interface{}{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		tflog.Debug(ctx, "scheduled post was published", map[string]interface{}{"scheduled_id": data.Id.ValueString(), "id": string(published.ID)})
+		data.Id = types.StringValue(string(published.ID))
+	}
+
+	post, err := r.client.GetStatus(ctx, mastodon.ID(data.Id.ValueString()))
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read post, got error: %s", err))
 		return
 	}
 
-	p := bluemonday.NewPolicy()
+	content := data.Content
+	data.setStatus(post)
+	data.keepEquivalentContent(content)
+	data.keepContentWithoutParentMention(content)
+	if r.client.truncateOverLimit {
+		data.keepTruncatedContent(content, r.client.truncationSuffix)
+	}
 
-	data.Id = types.StringValue(string(post.ID))
-	data.CreatedAt = types.StringValue(post.CreatedAt.String())
-	data.Account = types.StringValue(string(post.Account.ID))
-	data.Content = types.StringValue(p.Sanitize(post.Content))
-	data.Visibility = types.StringValue(post.Visibility)
-	data.Sensitive = types.BoolValue(post.Sensitive)
+	// The server renders formatted content to HTML, so the source it was
+	// written in is read back instead.
+	if data.hasFormattedContent() {
+		source, err := r.client.GetStatusSource(ctx, post.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read post source, got error: %s", err))
+			return
+		}
+		data.Content = types.StringValue(source.Text)
+	}
 
-	// During imports the `preserve_on_destroy` attribute may not be set.
+	// During imports the `preserve_on_destroy` and `auto_mention_parent`
+	// attributes may not be set.
 	if data.PreserveOnDestroy.IsNull() {
 		data.PreserveOnDestroy = types.BoolValue(false)
 	}
+	if data.AutoMentionParent.IsNull() {
+		data.AutoMentionParent = types.BoolValue(false)
+	}
+	if data.InheritVisibility.IsNull() {
+		data.InheritVisibility = types.BoolValue(false)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -202,40 +958,122 @@ func (r *PostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 func (r *PostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data PostResourceModel
+	var state PostResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	r = r.withAccessToken(ctx, data.AccessToken, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The key is only used to create the post, and imported posts have none.
+	if data.IdempotencyKey.IsUnknown() {
+		data.IdempotencyKey = state.IdempotencyKey
+	}
+
 	toot := mastodon.Toot{
-		Status:     data.Content.ValueString(),
-		Visibility: data.Visibility.ValueString(),
-		Sensitive:  data.Sensitive.ValueBool(),
+		Status:      data.Content.ValueString(),
+		Visibility:  data.Visibility.ValueString(),
+		Sensitive:   data.Sensitive.ValueBool(),
+		SpoilerText: data.SpoilerText.ValueString(),
+		Language:    data.Language.ValueString(),
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping post update.")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
 
-	post, err := r.client.UpdateStatus(context.Background(), &toot, mastodon.ID(data.Id.ValueString()))
+	// Any other change to a scheduled post replaces it, so only the time
+	// can have changed.
+	if data.Scheduled.ValueBool() {
+		scheduledAt, err := data.scheduledAt()
+		if err != nil || scheduledAt == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("scheduled_at"), "Invalid Scheduled Time", fmt.Sprintf("Unable to reschedule post: %v", err))
+			return
+		}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read post, got error: %s", err))
+		scheduled, err := r.client.rescheduleStatus(ctx, data.Id.ValueString(), *scheduledAt)
+		if isNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Scheduled Post Already Published",
+				fmt.Sprintf("Scheduled post %s was published or cancelled since it was last read. Run terraform apply again to pick up the published post.", data.Id.ValueString()),
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reschedule post, got error: %s", err))
+			return
+		}
+
+		data.setScheduledStatus(scheduled, r.client.currentAccountID())
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 		return
 	}
 
-	p := bluemonday.NewPolicy()
+	// Pinning is not part of the post, so the post is only edited when
+	// something else changed, as edits are shown to everyone.
+	var post *mastodon.Status
+	if data.needsEdit(state) {
+		var diags diag.Diagnostics
+		post, diags = r.editPost(ctx, &data, &toot)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		var err error
+		post, err = r.client.GetStatus(ctx, mastodon.ID(data.Id.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read post, got error: %s", err))
+			return
+		}
+	}
 
-	data.Id = types.StringValue(string(post.ID))
-	data.CreatedAt = types.StringValue(post.CreatedAt.String())
-	data.Account = types.StringValue(string(post.Account.ID))
-	data.Content = types.StringValue(p.Sanitize(post.Content))
-	data.Visibility = types.StringValue(post.Visibility)
-	data.Sensitive = types.BoolValue(post.Sensitive)
+	content := data.Content
+	pinned := data.Pinned
+	data.setStatus(post)
+	data.keepEquivalentContent(content)
+	data.keepContentWithoutParentMention(content)
+	data.keepFormattedContent(content)
+	if r.client.truncateOverLimit {
+		data.keepTruncatedContent(content, r.client.truncationSuffix)
+	}
+
+	if !pinned.Equal(data.Pinned) {
+		if err := r.client.setPinned(ctx, post.ID, pinned.ValueBool()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pinned"), "Unable to Pin Post", pinErrorDetail(string(post.ID), err))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+		data.Pinned = pinned
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// needsEdit reports whether the plan changes anything stored on the post,
+// which can only be changed by editing it.
+func (data *PostResourceModel) needsEdit(state PostResourceModel) bool {
+	return !data.Content.Equal(state.Content) ||
+		!data.ContentType.Equal(state.ContentType) ||
+		!data.Visibility.Equal(state.Visibility) ||
+		!data.Sensitive.Equal(state.Sensitive) ||
+		!data.SpoilerText.Equal(state.SpoilerText) ||
+		!data.Language.Equal(state.Language) ||
+		!data.MediaIds.Equal(state.MediaIds) ||
+		!data.Poll.Equal(state.Poll)
+}
+
 func (r *PostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data PostResourceModel
 
@@ -248,10 +1086,62 @@ func (r *PostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	if data.PreserveOnDestroy.ValueBool() {
 		tflog.Debug(ctx, "preserve_on_destroy is enabled: preserving post on server.")
+		if r.client.importBlocks != nil && data.Id.ValueString() != validateOnlyID {
+			if err := r.client.importBlocks.write("mastodon_post", data.Id.ValueString()); err != nil {
+				resp.Diagnostics.AddWarning(
+					"Unable to Write Import Block",
+					fmt.Sprintf("The post was preserved, but its import block could not be written to generate_import_blocks_path: %s", err),
+				)
+			}
+		}
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping post deletion.")
+		return
+	}
+
+	r = r.withAccessToken(ctx, data.AccessToken, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := r.client.DeleteStatus(context.Background(), mastodon.ID(data.Id.ValueString()))
+	// Scheduled posts were never published, so there is nothing to archive.
+	if data.Scheduled.ValueBool() {
+		err := r.client.deleteScheduledStatus(ctx, data.Id.ValueString())
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to cancel scheduled post, got error: %s", err))
+		}
+		return
+	}
+
+	if r.client.archive != nil {
+		// Prefer the source text the post was written with over the rendered
+		// HTML, but fall back to the state if the server cannot provide it.
+		text := data.Content.ValueString()
+		spoilerText := data.SpoilerText.ValueString()
+		if source, err := r.client.GetStatusSource(ctx, mastodon.ID(data.Id.ValueString())); err == nil {
+			text = source.Text
+			spoilerText = source.SpoilerText
+		}
+
+		err := r.client.archive.write(archivedPost{
+			Id:          data.Id.ValueString(),
+			CreatedAt:   data.CreatedAt.ValueString(),
+			Account:     data.Account.ValueString(),
+			Visibility:  data.Visibility.ValueString(),
+			SpoilerText: spoilerText,
+			Content:     text,
+			DeletedAt:   time.Now().UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Archive Error", fmt.Sprintf("Unable to archive post before deleting it, got error: %s", err))
+			return
+		}
+	}
+
+	err := r.client.DeleteStatus(ctx, mastodon.ID(data.Id.ValueString()))
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete post, got error: %s", err))
@@ -260,6 +1150,665 @@ func (r *PostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 }
 
+func (r *PostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PostResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := data.scheduledAt(); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scheduled_at"), "Invalid Scheduled Time", err.Error())
+	}
+
+	// A scheduled post does not exist until it is published, so there is
+	// nothing to pin yet.
+	if !data.ScheduledAt.IsNull() && data.Pinned.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pinned"),
+			"Scheduled Post Cannot Be Pinned",
+			"A scheduled post cannot be pinned. Set pinned once the post is published and scheduled_at is removed.",
+		)
+	}
+
+	if data.Content.IsUnknown() || strings.TrimSpace(data.Content.ValueString()) != "" {
+		return
+	}
+
+	// Mastodon only accepts a post without text when something is attached.
+	if data.Attachments.IsUnknown() || len(data.Attachments.Elements()) > 0 {
+		return
+	}
+	if data.MediaIds.IsUnknown() || len(data.MediaIds.Elements()) > 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("content"),
+		"Empty Post",
+		"A post must have content unless media is attached. Set content, or add attachments or media_ids.",
+	)
+}
+
+func (r *PostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config PostResourceModel
+	var plan PostResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r = r.withAccessToken(ctx, plan.AccessToken, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.checkBotAccount(); err != nil {
+		resp.Diagnostics.AddError(
+			"Bot Account Required",
+			fmt.Sprintf("The provider's require_bot_account is set, but %s. "+
+				"Enable \"This is an automated account\" in the account's profile settings, or post from a bot account.", err),
+		)
+		return
+	}
+
+	if plan.InheritVisibility.ValueBool() && config.Visibility.IsNull() && !plan.InReplyToId.IsNull() {
+		resp.Diagnostics.Append(r.inheritParentVisibility(ctx, req, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+
+	// A scheduled post cannot be edited, only moved to another time, so
+	// any other change schedules a new post.
+	if !req.State.Raw.IsNull() {
+		var state PostResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Scheduled.ValueBool() {
+			resp.RequiresReplace = append(resp.RequiresReplace, scheduledPostChanges(plan, state)...)
+		}
+	}
+
+	// Posts being replaced anyway can take any value.
+	if !req.State.Raw.IsNull() && len(resp.RequiresReplace) == 0 {
+		var state PostResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Id.ValueString() != validateOnlyID {
+			r.applyImmutableFieldPolicy(plan, state, resp)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	if config.Sensitive.IsNull() && !plan.Visibility.IsUnknown() {
+		if sensitive, ok := r.client.defaultSensitiveByVisibility[plan.Visibility.ValueString()]; ok {
+			plan.Sensitive = types.BoolValue(sensitive)
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+	}
+
+	// An explicit content warning always wins over the keyword policy. The
+	// post is marked sensitive along with any other content warning below.
+	if len(r.client.autoCWKeywords) > 0 && config.SpoilerText.IsNull() && !plan.Content.IsUnknown() {
+		if spoilerText, ok := matchContentWarning(plan.Content.ValueString(), r.client.autoCWKeywords); ok {
+			tflog.Debug(ctx, "applying content warning from auto_cw_keywords")
+			plan.SpoilerText = types.StringValue(spoilerText)
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+	}
+
+	// Mastodon marks every post with a content warning as sensitive, so
+	// planning anything else would never converge.
+	if !plan.SpoilerText.IsUnknown() && plan.SpoilerText.ValueString() != "" {
+		if config.Sensitive.IsNull() {
+			plan.Sensitive = types.BoolValue(true)
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		} else if !config.Sensitive.IsUnknown() && !config.Sensitive.ValueBool() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("sensitive"),
+				"Post With Content Warning Is Sensitive",
+				"The post has a content warning but sensitive is set to false. "+
+					"Mastodon marks posts with a content warning as sensitive anyway, so the post will keep showing changes. Remove sensitive, or set it to true.",
+			)
+		}
+	}
+
+	// The key follows the post, so it is only known once the post is, and
+	// an existing post keeps its key until the post changes.
+	if config.IdempotencyKey.IsNull() {
+		key, ok := plan.idempotencyKey(r.client.currentAccountID())
+		var state PostResourceModel
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		}
+		switch {
+		case resp.Diagnostics.HasError():
+			return
+		case !ok:
+			plan.IdempotencyKey = types.StringUnknown()
+		case req.State.Raw.IsNull():
+			plan.IdempotencyKey = types.StringValue(key)
+		default:
+			if stateKey, _ := state.idempotencyKey(r.client.currentAccountID()); stateKey != key {
+				plan.IdempotencyKey = types.StringValue(key)
+			}
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+
+	if !plan.ContentType.IsNull() && !plan.ContentType.IsUnknown() {
+		if err := r.client.checkContentType(ctx, plan.ContentType.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_type"),
+				"Unsupported Content Type",
+				fmt.Sprintf("Unable to post in %s: %s.", plan.ContentType.ValueString(), err),
+			)
+		}
+	}
+
+	if !plan.Attachments.IsNull() && !plan.Attachments.IsUnknown() {
+		if limit := r.client.maxMediaAttachments(ctx); len(plan.Attachments.Elements()) > limit {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("attachments"),
+				"Too Many Attachments",
+				fmt.Sprintf("The post has %d attachments, but the server allows at most %d per post.", len(plan.Attachments.Elements()), limit),
+			)
+		}
+	}
+	if !config.MediaIds.IsNull() && !config.MediaIds.IsUnknown() {
+		if limit := r.client.maxMediaAttachments(ctx); len(config.MediaIds.Elements()) > limit {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("media_ids"),
+				"Too Many Attachments",
+				fmt.Sprintf("The post has %d media IDs, but the server allows at most %d per post.", len(config.MediaIds.Elements()), limit),
+			)
+		}
+		if r.client.mediaCache != nil {
+			r.checkMediaUnattached(ctx, req.State, config, &resp.Diagnostics)
+		}
+	}
+
+	poll, diags := config.postPoll(ctx)
+	resp.Diagnostics.Append(diags...)
+	if poll != nil {
+		for _, problem := range r.client.pollLimits(ctx).checkPoll(poll) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll"),
+				"Poll Exceeds Server Limits",
+				fmt.Sprintf("Unable to post the poll: %s.", problem),
+			)
+		}
+	}
+
+	if r.client.truncateOverLimit && !plan.Content.IsUnknown() && !plan.SpoilerText.IsUnknown() {
+		content, spoilerText := plan.Content.ValueString(), plan.SpoilerText.ValueString()
+		limit, reservedPerURL := r.client.postLimits(ctx)
+		switch {
+		case statusLength(r.client.truncationSuffix, spoilerText, reservedPerURL) > limit:
+			// Only the content is truncated, so no truncation makes it fit.
+			resp.Diagnostics.AddAttributeError(
+				path.Root("spoiler_text"),
+				"Post Too Long",
+				fmt.Sprintf("The content warning is %d characters long, which leaves no room for the content within the server's limit of %d.", utf8.RuneCountInString(spoilerText), limit),
+			)
+		case statusLength(content, spoilerText, reservedPerURL) > limit:
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("content"),
+				"Post Will Be Truncated",
+				fmt.Sprintf("The post is %d characters long including its content warning, but the server allows at most %d. "+
+					"It will be truncated to fit and end with %q.", statusLength(content, spoilerText, reservedPerURL), limit, r.client.truncationSuffix),
+			)
+		}
+	}
+
+	if !r.client.truncateOverLimit && !plan.Content.IsUnknown() && !plan.SpoilerText.IsUnknown() {
+		resp.Diagnostics.Append(r.checkPostLength(ctx, req.State, plan)...)
+	}
+
+	if r.client.warnLanguageMismatch && !config.Language.IsNull() && !config.Language.IsUnknown() && !plan.Content.IsUnknown() {
+		if script, mismatch := languageScriptMismatch(config.Language.ValueString(), plan.Content.ValueString()); mismatch {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("language"),
+				"Post Language May Not Match Content",
+				fmt.Sprintf("The post is tagged with language %q but its content is mostly written in %s script. "+
+					"A mismatched language can hide the post from people filtering by language.", config.Language.ValueString(), script),
+			)
+		}
+	}
+}
+
+// checkPostLength reports new or changed content that is longer than the
+// server allows. No server allows less than Mastodon's default, so the
+// server's limits are only read for posts longer than that.
+func (r *PostResource) checkPostLength(ctx context.Context, priorState tfsdk.State, plan PostResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !priorState.Raw.IsNull() {
+		var state PostResourceModel
+		diags.Append(priorState.Get(ctx, &state)...)
+		if diags.HasError() || (state.Content.Equal(plan.Content) && state.SpoilerText.Equal(plan.SpoilerText)) {
+			return diags
+		}
+	}
+
+	content, spoilerText := plan.Content.ValueString(), plan.SpoilerText.ValueString()
+	if statusLength(content, spoilerText, defaultCharactersReservedPerURL) <= defaultMaxPostCharacters {
+		return diags
+	}
+
+	limit, reservedPerURL := r.client.postLimits(ctx)
+	if length := statusLength(content, spoilerText, reservedPerURL); length > limit {
+		diags.AddAttributeError(
+			path.Root("content"),
+			"Post Too Long",
+			fmt.Sprintf("The post is %d characters long, counting its content warning, but the server allows at most %d. "+
+				"Links count as %d characters each and mentions of remote accounts count without their domain. "+
+				"Shorten the post, or set the provider's truncate_over_limit to truncate it automatically.", length, limit, reservedPerURL),
+		)
+	}
+	return diags
+}
+
 func (r *PostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := req.ID
+
+	// Posts can also be imported by their public URL.
+	if strings.HasPrefix(id, "https://") || strings.HasPrefix(id, "http://") {
+		if err := r.client.checkLocalURL(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Cannot Import Post From Another Instance",
+				fmt.Sprintf("Unable to import post: %s. Import it with the provider configuration for its instance.", err),
+			)
+			return
+		}
+
+		results, err := r.client.Search(ctx, id, true)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve post URL, got error: %s", err))
+			return
+		}
+		if len(results.Statuses) != 1 {
+			resp.Diagnostics.AddError(
+				"Unable to Resolve Post URL",
+				fmt.Sprintf("Expected the URL %q to resolve to exactly one post, got %d.", id, len(results.Statuses)),
+			)
+			return
+		}
+		id = string(results.Statuses[0].ID)
+	}
+
+	post, err := r.client.GetStatus(ctx, mastodon.ID(id))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read post, got error: %s", err))
+		return
+	}
+
+	// A remote post known to this instance has a local ID, but lives on its
+	// home instance.
+	if err := r.client.checkLocalURL(post.URI); err != nil {
+		resp.Diagnostics.AddError(
+			"Cannot Import Post From Another Instance",
+			fmt.Sprintf("Unable to import post %s: %s. Import it with the provider configuration for its instance.", id, err),
+		)
+		return
+	}
+
+	// Only posts owned by the authenticated account can be edited.
+	if post.Account.ID != r.client.currentUser.ID {
+		resp.Diagnostics.AddError(
+			"Cannot Import Post Owned by Another Account",
+			fmt.Sprintf("Post %s belongs to %s, but the provider is authenticated as %s. Only your own posts can be managed by mastodon_post.",
+				id, post.Account.Acct, r.client.currentUser.Acct),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (r *PostResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored created_at in Go's default time format. Only that
+		// attribute changed, so the state is read with the current schema.
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var schemaResp resource.SchemaResponse
+				r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+				raw, err := req.RawState.UnmarshalWithOpts(schemaResp.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+					ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+				})
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade State", fmt.Sprintf("Unable to read the prior post state, got error: %s", err))
+					return
+				}
+				resp.State = tfsdk.State{Schema: schemaResp.Schema, Raw: raw}
+
+				var createdAt types.String
+				resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("created_at"), &createdAt)...)
+				if upgraded, ok := rfc3339FromGoTime(createdAt.ValueString()); ok {
+					resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("created_at"), upgraded)...)
+				}
+			},
+		},
+	}
+}
+
+// rfc3339FromGoTime converts a timestamp in Go's default time format, as
+// stored by version 0 of the post state, to RFC 3339.
+func rfc3339FromGoTime(value string) (string, bool) {
+	parsed, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", value)
+	if err != nil {
+		return "", false
+	}
+	return parsed.Format(time.RFC3339), true
+}
+
+// matchContentWarning returns the content warning for the first keyword,
+// in lexical order, that appears in the content. Matching is case-insensitive.
+func matchContentWarning(content string, keywords map[string]string) (string, bool) {
+	content = strings.ToLower(content)
+
+	sorted := make([]string, 0, len(keywords))
+	for keyword := range keywords {
+		sorted = append(sorted, keyword)
+	}
+	sort.Strings(sorted)
+
+	for _, keyword := range sorted {
+		if strings.Contains(content, strings.ToLower(keyword)) {
+			return keywords[keyword], true
+		}
+	}
+	return "", false
+}
+
+// editPost edits a published post with the planned changes.
+func (r *PostResource) editPost(ctx context.Context, data *PostResourceModel, toot *mastodon.Toot) (*mastodon.Status, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if r.client.resolveMentions {
+		r.warnUnresolvedMentions(ctx, data.Content.ValueString(), &diags)
+	}
+
+	if data.AutoMentionParent.ValueBool() && !data.InReplyToId.IsNull() {
+		status, err := r.mentionParent(ctx, data.InReplyToId.ValueString(), toot.Status)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read parent post, got error: %s", err))
+			return nil, diags
+		}
+		toot.Status = status
+	}
+
+	if status, truncated := r.client.truncateContent(ctx, toot.Status, toot.SpoilerText); truncated {
+		tflog.Debug(ctx, "truncating post content over the server's limit")
+		toot.Status = status
+	}
+
+	// Media left out of an edit is removed from the post, so the current
+	// media is always sent.
+	mediaIDs, d := data.mediaIDs(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	toot.MediaIDs = mediaIDs
+
+	// The same goes for the poll, which is restarted from the duration sent.
+	poll, d := data.postPoll(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if poll != nil {
+		tootPoll, err := poll.remainingTootPoll(time.Now())
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to edit post with a poll: %s", err))
+			return nil, diags
+		}
+		minimum := int64(r.client.pollLimits(ctx).MinExpiration)
+		if minimum == 0 {
+			minimum = defaultMinPollExpiration
+		}
+		if tootPoll.ExpiresInSeconds < minimum {
+			diags.AddError(
+				"Unable to Edit Post With Poll",
+				fmt.Sprintf("Post %s has a poll that closed or closes in less than %d seconds, and Mastodon cannot edit it without reopening the poll. "+
+					"Revert the change, or replace the post with -replace.", data.Id.ValueString(), minimum),
+			)
+			return nil, diags
+		}
+		toot.Poll = tootPoll
+	}
+
+	post, err := r.client.updateStatus(ctx, mastodon.ID(data.Id.ValueString()), toot, data.ContentType.ValueString())
+
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read post, got error: %s", err))
+		return nil, diags
+	}
+	return post, diags
+}
+
+// withAccessToken returns the resource acting as the account of the
+// overriding access token, or the resource itself when there is none.
+// checkMediaUnattached adds an error for media the post is planned to be
+// given that the server no longer lists as unattached. With
+// deduplicate_media, identical mastodon_media share an ID that only one post
+// can be given.
+func (r *PostResource) checkMediaUnattached(ctx context.Context, state tfsdk.State, config PostResourceModel, diags *diag.Diagnostics) {
+	var current []string
+	if !state.Raw.IsNull() {
+		var prior PostResourceModel
+		diags.Append(state.Get(ctx, &prior)...)
+		if !prior.MediaIds.IsNull() {
+			diags.Append(prior.MediaIds.ElementsAs(ctx, &current, false)...)
+		}
+	}
+
+	if diags.HasError() {
+		return
+	}
+	for i, element := range config.MediaIds.Elements() {
+		// Media uploaded in the same run is not known yet, and media the
+		// post already has is attached to it.
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() || slices.Contains(current, value.ValueString()) {
+			continue
+		}
+		id := value.ValueString()
+		_, err := r.client.getMedia(ctx, id)
+		if isNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("media_ids").AtListIndex(i),
+				"Media Already Attached",
+				fmt.Sprintf("Media %s is attached to another post or no longer exists. "+
+					"deduplicate_media gives identical mastodon_media the same ID, but Mastodon attaches media to a single post. "+
+					"Change the description or focus of one of them, or disable deduplicate_media.", id),
+			)
+		} else if err != nil {
+			tflog.Debug(ctx, "unable to check whether media is attached", map[string]interface{}{"id": id, "error": err.Error()})
+		}
+	}
+}
+
+func (r *PostResource) withAccessToken(ctx context.Context, token types.String, diags *diag.Diagnostics) *PostResource {
+	if token.IsNull() || token.IsUnknown() {
+		return r
+	}
+
+	client, err := r.client.forAccessToken(ctx, token.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return r
+	}
+	return &PostResource{client: client}
+}
+
+// warnUnresolvedMentions resolves the remote mentions in the content and warns
+// about those the server could not find, as they will not be delivered.
+func (r *PostResource) warnUnresolvedMentions(ctx context.Context, content string, diags *diag.Diagnostics) {
+	for _, handle := range r.client.unresolvedMentions(ctx, content) {
+		diags.AddAttributeWarning(
+			path.Root("content"),
+			"Unresolved Mention",
+			fmt.Sprintf("The server could not resolve @%s, so the account will not be notified of this post. Check the handle for typos.", handle),
+		)
+	}
+}
+
+// inheritParentVisibility plans the visibility of a reply from its parent
+// post. The visibility is left unknown until the parent is created.
+func (r *PostResource) inheritParentVisibility(ctx context.Context, req resource.ModifyPlanRequest, plan *PostResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.InReplyToId.IsUnknown() {
+		plan.Visibility = types.StringUnknown()
+		return diags
+	}
+
+	// The parent of an existing reply cannot change, so the visibility it
+	// inherited is kept without reading the parent again.
+	if !req.State.Raw.IsNull() {
+		var state PostResourceModel
+		diags.Append(req.State.Get(ctx, &state)...)
+		if state.InReplyToId.Equal(plan.InReplyToId) && !state.Visibility.IsNull() {
+			plan.Visibility = state.Visibility
+			return diags
+		}
+	}
+
+	visibility, err := r.parentVisibility(ctx, plan.InReplyToId.ValueString(), plan.Visibility.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("in_reply_to_id"),
+			"Unable to Inherit Parent Visibility",
+			fmt.Sprintf("Unable to read parent post %s, got error: %s", plan.InReplyToId.ValueString(), err),
+		)
+		return diags
+	}
+	plan.Visibility = types.StringValue(visibility)
+	return diags
+}
+
+// applyImmutableFieldPolicy handles plans that change a field the server
+// cannot edit on an existing post, according to `immutable_field_policy`.
+// Terraform rejects plans that differ from the configuration, so keeping the
+// existing value is left to `ignore_changes`.
+func (r *PostResource) applyImmutableFieldPolicy(plan PostResourceModel, state PostResourceModel, resp *resource.ModifyPlanResponse) {
+	var changed []path.Path
+	if !plan.Visibility.IsUnknown() && !plan.Visibility.Equal(state.Visibility) {
+		changed = append(changed, path.Root("visibility"))
+	}
+	if !plan.Attachments.IsUnknown() && !plan.Attachments.Equal(state.Attachments) {
+		changed = append(changed, path.Root("attachments"))
+	}
+
+	for _, attribute := range changed {
+		switch r.client.immutableFieldPolicy {
+		case immutableFieldPolicyRecreate:
+			resp.RequiresReplace = append(resp.RequiresReplace, attribute)
+		default:
+			resp.Diagnostics.AddAttributeError(
+				attribute,
+				"Immutable Post Field Changed",
+				fmt.Sprintf("Mastodon cannot change the %s of an existing post, but the plan changes it for post %s. "+
+					"Revert the change, replace the post with -replace, set the provider's immutable_field_policy to \"recreate\", "+
+					"or add %s to the resource's lifecycle ignore_changes to keep the current value.", attribute, state.Id.ValueString(), attribute),
+			)
+		}
+	}
+}
+
+// scheduledPostChanges returns the attributes the plan changes on a
+// scheduled post, other than the time it is scheduled for.
+func scheduledPostChanges(plan PostResourceModel, state PostResourceModel) path.Paths {
+	var changed path.Paths
+	for name, values := range map[string][2]attr.Value{
+		"content":      {plan.Content, state.Content},
+		"content_type": {plan.ContentType, state.ContentType},
+		"visibility":   {plan.Visibility, state.Visibility},
+		"sensitive":    {plan.Sensitive, state.Sensitive},
+		"spoiler_text": {plan.SpoilerText, state.SpoilerText},
+		"language":     {plan.Language, state.Language},
+		"attachments":  {plan.Attachments, state.Attachments},
+		"media_ids":    {plan.MediaIds, state.MediaIds},
+		"poll":         {plan.Poll, state.Poll},
+	} {
+		if !values[0].Equal(values[1]) {
+			changed = append(changed, path.Root(name))
+		}
+	}
+
+	// Without a time, the post has to be published right away.
+	if plan.ScheduledAt.IsNull() {
+		changed = append(changed, path.Root("scheduled_at"))
+	}
+
+	sort.Slice(changed, func(i, j int) bool { return changed[i].String() < changed[j].String() })
+	return changed
+}
+
+// publishedStatus returns the post a scheduled post was published as, or nil
+// when it was cancelled.
+func (r *PostResource) publishedStatus(ctx context.Context, data *PostResourceModel) (*mastodon.Status, error) {
+	scheduledAt, err := data.scheduledAt()
+	if err != nil || scheduledAt == nil || r.client.currentUser == nil {
+		return nil, err
+	}
+	return r.client.findPublishedStatus(ctx, r.client.currentUser.ID, *scheduledAt, func(status *mastodon.Status) bool {
+		return data.isPublishedAs(status, *scheduledAt)
+	})
+}
+
+// parentVisibility returns the visibility of a reply to the parent post,
+// which is the given visibility unless the parent is more restricted.
+func (r *PostResource) parentVisibility(ctx context.Context, parentID string, visibility string) (string, error) {
+	parent, err := r.client.GetStatus(ctx, mastodon.ID(parentID))
+	if err != nil {
+		return "", err
+	}
+	return stricterVisibility(visibility, parent.Visibility), nil
+}
+
+// stricterVisibility returns whichever visibility reaches fewer people.
+// Unrecognised visibilities are ignored.
+func stricterVisibility(a string, b string) string {
+	if slices.Index(postVisibilities, b) > slices.Index(postVisibilities, a) {
+		return b
+	}
+	return a
+}
+
+// mentionParent prepends a mention of the author of the parent post to the
+// content of a reply.
+func (r *PostResource) mentionParent(ctx context.Context, parentID string, content string) (string, error) {
+	parent, err := r.client.GetStatus(ctx, mastodon.ID(parentID))
+	if err != nil {
+		return "", err
+	}
+
+	self := ""
+	if r.client.currentUser != nil {
+		self = r.client.currentUser.Acct
+	}
+	return prependMention(content, parent.Account.Acct, self), nil
 }
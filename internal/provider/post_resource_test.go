@@ -1,10 +1,34 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccPostResource(t *testing.T) {
@@ -17,14 +41,19 @@ func TestAccPostResource(t *testing.T) {
 				Config: testAccPostResourceConfig("First Test Post"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("mastodon_post.test", "content", "First Test Post"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "content_html", "<p>First Test Post</p>"),
 					resource.TestCheckResourceAttr("mastodon_post.test", "visibility", "public"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "favourited", "false"),
+					resource.TestCheckResourceAttrSet("mastodon_post.test", "uri"),
+					resource.TestCheckResourceAttrSet("mastodon_post.test", "url"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "mastodon_post.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "mastodon_post.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
 			},
 			// Update and Read testing
 			{
@@ -45,3 +74,1193 @@ resource "mastodon_post" "test" {
 }
 `, content)
 }
+
+func TestAccPostResource_Pinned(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostResourcePinnedConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "pinned", "true"),
+				),
+			},
+			// Unpinning does not edit the post.
+			{
+				Config: testAccPostResourcePinnedConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "pinned", "false"),
+					resource.TestCheckNoResourceAttr("mastodon_post.test", "edited_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPostResourcePinnedConfig(pinned bool) string {
+	return fmt.Sprintf(`
+resource "mastodon_post" "test" {
+  content = "Pinned Test Post"
+  pinned  = %t
+}
+`, pinned)
+}
+
+func TestAccPostResource_ValidateOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mastodon" {
+  validate_only = true
+}
+` + testAccPostResourceConfig("Validate Only Post"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "id", validateOnlyID),
+					resource.TestCheckResourceAttr("mastodon_post.test", "content", "Validate Only Post"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_DefaultSensitiveByVisibility(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mastodon" {
+  validate_only = true
+
+  default_sensitive_by_visibility = {
+    direct = true
+    public = false
+  }
+}
+
+resource "mastodon_post" "direct" {
+  content    = "@tedivm@hachyderm.io Direct Post"
+  visibility = "direct"
+}
+
+resource "mastodon_post" "public" {
+  content    = "Public Post"
+  visibility = "public"
+}
+
+resource "mastodon_post" "explicit" {
+  content    = "@tedivm@hachyderm.io Explicit Post"
+  visibility = "direct"
+  sensitive  = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.direct", "sensitive", "true"),
+					resource.TestCheckResourceAttr("mastodon_post.public", "sensitive", "false"),
+					resource.TestCheckResourceAttr("mastodon_post.explicit", "sensitive", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_Card(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostResourceConfig("Link Post https://github.com/TerraformInDepth/terraform-provider-mastodon"),
+			},
+			// The server fetches the preview card in the background, so it is
+			// checked after a refresh.
+			{
+				Config: testAccPostResourceConfig("Link Post https://github.com/TerraformInDepth/terraform-provider-mastodon"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "card.url", "https://github.com/TerraformInDepth/terraform-provider-mastodon"),
+					resource.TestCheckResourceAttrSet("mastodon_post.test", "card.title"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_MentionsAndTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostResourceConfig("Hello @tedivm@hachyderm.io, welcome to #terraform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "mentions.#", "1"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "mentions.0.acct", "tedivm@hachyderm.io"),
+					resource.TestCheckResourceAttrSet("mastodon_post.test", "mentions.0.id"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "tags.0.name", "terraform"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_Poll(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mastodon_post" "test" {
+  content = "Tabs or spaces?"
+
+  poll = {
+    options    = ["Tabs", "Spaces"]
+    expires_in = 86400
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "poll.options.#", "2"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "poll.multiple", "false"),
+					resource.TestCheckResourceAttrSet("mastodon_post.test", "poll.expires_at"),
+				),
+			},
+			// Editing the text keeps the poll.
+			{
+				Config: `
+resource "mastodon_post" "test" {
+  content = "Tabs or spaces? Vote now!"
+
+  poll = {
+    options    = ["Tabs", "Spaces"]
+    expires_in = 86400
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "poll.options.1", "Spaces"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_Scheduled(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "mastodon_post" "test" {
+  content      = "Scheduled Post"
+  scheduled_at = %q
+}
+`, scheduledAt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "scheduled", "true"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "scheduled_at", scheduledAt),
+					resource.TestCheckNoResourceAttr("mastodon_post.test", "url"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_Reply(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mastodon_post" "parent" {
+  content = "Thread Parent"
+}
+
+resource "mastodon_post" "reply" {
+  content             = "Thread Reply"
+  in_reply_to_id      = mastodon_post.parent.id
+  auto_mention_parent = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("mastodon_post.reply", "in_reply_to_id", "mastodon_post.parent", "id"),
+					// Replying to our own post adds no mention.
+					resource.TestCheckResourceAttr("mastodon_post.reply", "content", "Thread Reply"),
+				),
+			},
+		},
+	})
+}
+
+func TestPostResource_MentionParent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/statuses/109372843234" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mastodon.Status{
+			ID:      "109372843234",
+			Account: mastodon.Account{ID: "1", Acct: "tedivm@hachyderm.io"},
+		})
+	}))
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		currentUser: &mastodon.Account{ID: "2", Acct: "me"},
+	}}
+
+	content, err := r.mentionParent(context.Background(), "109372843234", "Great post!")
+	assert.NoError(t, err)
+	assert.Equal(t, "@tedivm@hachyderm.io Great post!", content)
+
+	_, err = r.mentionParent(context.Background(), "404", "Great post!")
+	assert.Error(t, err)
+}
+
+func TestAccPostResource_InheritParentVisibility(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "mastodon_post" "parent" {
+  content    = "Private Thread Parent"
+  visibility = "private"
+}
+
+resource "mastodon_post" "reply" {
+  content                   = "Private Thread Reply"
+  in_reply_to_id            = mastodon_post.parent.id
+  inherit_parent_visibility = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.reply", "visibility", "private"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_Attachments(t *testing.T) {
+	image, err := filepath.Abs("testdata/pixel.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "mastodon_post" "test" {
+  content = "Post With Inline Image"
+
+  attachments = [
+    {
+      file_path   = %q
+      description = "A single pixel"
+      focus       = "0.0,0.5"
+    },
+  ]
+}
+`, image),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "attachments.#", "1"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "attachments.0.description", "A single pixel"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "media_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_AccessToken(t *testing.T) {
+	token := os.Getenv("MASTODON_TEST_OVERRIDE_ACCESS_TOKEN")
+	if token == "" {
+		t.Skip("MASTODON_TEST_OVERRIDE_ACCESS_TOKEN must be set to a second account's token to test access token overrides")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "mastodon_post" "test" {
+  content      = "Posted Under Another Account"
+  access_token = %q
+}
+`, token),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "content", "Posted Under Another Account"),
+					resource.TestCheckResourceAttrWith("mastodon_post.test", "account", func(value string) error {
+						user, err := testAccClient().GetAccountCurrentUser(context.Background())
+						if err != nil {
+							return err
+						}
+						if value == string(user.ID) {
+							return fmt.Errorf("expected the post to be created by the overriding account, got the provider's account %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_UnsupportedContentType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The acceptance test server runs vanilla Mastodon, which only
+			// accepts plain text.
+			{
+				Config: `
+resource "mastodon_post" "test" {
+  content      = "**Markdown Post**"
+  content_type = "text/markdown"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unsupported Content Type`),
+			},
+			{
+				Config: `
+resource "mastodon_post" "test" {
+  content      = "Plain Post"
+  content_type = "text/plain"
+}
+`,
+				Check: resource.TestCheckResourceAttr("mastodon_post.test", "content", "Plain Post"),
+			},
+		},
+	})
+}
+
+func TestPostResource_ParentVisibility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/statuses/109372843234" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mastodon.Status{
+			ID:         "109372843234",
+			Visibility: "private",
+			Account:    mastodon.Account{ID: "1", Acct: "tedivm@hachyderm.io"},
+		})
+	}))
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{
+		Client: mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+	}}
+
+	visibility, err := r.parentVisibility(context.Background(), "109372843234", "public")
+	assert.NoError(t, err)
+	assert.Equal(t, "private", visibility)
+
+	// A reply is never broadened, but can be narrowed further.
+	visibility, err = r.parentVisibility(context.Background(), "109372843234", "direct")
+	assert.NoError(t, err)
+	assert.Equal(t, "direct", visibility)
+
+	_, err = r.parentVisibility(context.Background(), "404", "public")
+	assert.Error(t, err)
+}
+
+func TestStricterVisibility(t *testing.T) {
+	assert.Equal(t, "unlisted", stricterVisibility("public", "unlisted"))
+	assert.Equal(t, "direct", stricterVisibility("direct", "private"))
+	assert.Equal(t, "private", stricterVisibility("private", "private"))
+	assert.Equal(t, "public", stricterVisibility("public", "local"))
+}
+
+func TestPostResource_ImportFromAnotherInstance(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		host:        "mastodon.social",
+		currentUser: &mastodon.Account{ID: "1", Acct: "me", URL: "https://mastodon.social/@me"},
+	}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	resp := &fwresource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+		},
+	}
+	r.ImportState(context.Background(), fwresource.ImportStateRequest{ID: "https://hachyderm.io/@tedivm/109372843234"}, resp)
+
+	if assert.True(t, resp.Diagnostics.HasError()) {
+		assert.Equal(t, "Cannot Import Post From Another Instance", resp.Diagnostics.Errors()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "hosted on hachyderm.io, but this provider configuration is for mastodon.social")
+	}
+	assert.Zero(t, requests, "the mismatch is caught before contacting the server")
+}
+
+// testUnitProviderConfig configures the provider against a test server,
+// with any extra provider arguments.
+func testUnitProviderConfig(serverURL string, extra string) string {
+	return fmt.Sprintf(`
+provider "mastodon" {
+  host          = %q
+  client_id     = "client"
+  client_secret = "secret"
+  access_token  = "token"
+  %s
+}
+`, serverURL, extra)
+}
+
+// newPostServer serves the statuses API from memory, for tests that run
+// mastodon_post through Terraform.
+func newPostServer(t *testing.T) *httptest.Server {
+	var mutex sync.Mutex
+	statuses := map[string]map[string]interface{}{}
+	idempotencyKeys := map[string]string{}
+	nextID := 100
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/statuses/")
+		switch {
+		case r.URL.Path == "/api/v1/accounts/verify_credentials":
+			_, _ = w.Write([]byte(`{"id":"1","username":"me","acct":"me"}`))
+			return
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/statuses" && idempotencyKeys[r.Header.Get("Idempotency-Key")] != "":
+			// Like Mastodon, a known key is answered with the post created
+			// for it.
+			id = idempotencyKeys[r.Header.Get("Idempotency-Key")]
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/statuses":
+			assert.NoError(t, r.ParseForm())
+			nextID++
+			id = strconv.Itoa(nextID)
+			statuses[id] = map[string]interface{}{
+				"id":           id,
+				"uri":          "https://mastodon.example/users/me/statuses/" + id,
+				"created_at":   "2024-05-06T10:00:00.000Z",
+				"account":      map[string]string{"id": "1"},
+				"content":      "<p>" + html.EscapeString(r.PostForm.Get("status")) + "</p>",
+				"visibility":   r.PostForm.Get("visibility"),
+				"sensitive":    r.PostForm.Get("sensitive") == "true",
+				"spoiler_text": r.PostForm.Get("spoiler_text"),
+			}
+			if key := r.Header.Get("Idempotency-Key"); key != "" {
+				idempotencyKeys[key] = id
+			}
+		case statuses[id] == nil:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+			return
+		case r.Method == http.MethodDelete:
+			defer delete(statuses, id)
+		}
+		_ = json.NewEncoder(w).Encode(statuses[id])
+	}))
+}
+
+// testPostConfig builds a mastodon_post configuration from the given
+// attribute values, leaving every other attribute null.
+func testPostConfig(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	var schemaResp fwresource.SchemaResponse
+	(&PostResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}
+}
+
+func TestPostResource_ValidateConfigEmptyContent(t *testing.T) {
+	attachmentType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"file_path":   tftypes.String,
+		"description": tftypes.String,
+		"focus":       tftypes.String,
+	}}
+	attachments := tftypes.NewValue(tftypes.List{ElementType: attachmentType}, []tftypes.Value{
+		tftypes.NewValue(attachmentType, map[string]tftypes.Value{
+			"file_path":   tftypes.NewValue(tftypes.String, "testdata/pixel.png"),
+			"description": tftypes.NewValue(tftypes.String, "A single pixel"),
+			"focus":       tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+
+	r := &PostResource{}
+
+	// Empty content with media attached is allowed.
+	resp := &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: testPostConfig(t, map[string]tftypes.Value{
+		"content":     tftypes.NewValue(tftypes.String, ""),
+		"attachments": attachments,
+	})}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+
+	// So is empty content with already uploaded media.
+	resp = &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: testPostConfig(t, map[string]tftypes.Value{
+		"content": tftypes.NewValue(tftypes.String, ""),
+		"media_ids": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "110000000000000001"),
+		}),
+	})}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+
+	// Empty content alone is rejected, as is whitespace.
+	for _, content := range []string{"", " \n"} {
+		resp = &fwresource.ValidateConfigResponse{}
+		r.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: testPostConfig(t, map[string]tftypes.Value{
+			"content": tftypes.NewValue(tftypes.String, content),
+		})}, resp)
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Empty Post", resp.Diagnostics.Errors()[0].Summary())
+	}
+
+	resp = &fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: testPostConfig(t, map[string]tftypes.Value{
+		"content": tftypes.NewValue(tftypes.String, "Hello"),
+	})}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+}
+
+func TestMediaIDsValue(t *testing.T) {
+	attachments := []mastodon.Attachment{{ID: "2"}, {ID: "1"}}
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("2"), types.StringValue("1")}),
+		mediaIDsValue(attachments, types.ListNull(types.StringType)))
+
+	// A post without media keeps an omitted or empty value as it is.
+	assert.Equal(t, types.ListNull(types.StringType), mediaIDsValue(nil, types.ListNull(types.StringType)))
+	assert.Equal(t, empty, mediaIDsValue(nil, empty))
+	assert.Equal(t, types.ListNull(types.StringType), mediaIDsValue(nil, types.ListUnknown(types.StringType)))
+
+	// Media removed outside of Terraform shows up as drift.
+	assert.Equal(t, empty, mediaIDsValue(nil, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("1")})))
+}
+
+func TestPostResource_ImmutableFieldPolicy(t *testing.T) {
+	post := func(visibility string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":         tftypes.NewValue(tftypes.String, "109372843234"),
+			"content":    tftypes.NewValue(tftypes.String, "Hello"),
+			"visibility": tftypes.NewValue(tftypes.String, visibility),
+		}
+	}
+	modifyPlan := func(policy string) *fwresource.ModifyPlanResponse {
+		r := &PostResource{client: &MastodonClient{immutableFieldPolicy: policy}}
+		config := testPostConfig(t, post("private"))
+		req := fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   tfsdk.Plan(config),
+			State:  tfsdk.State(testPostConfig(t, post("public"))),
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+		return resp
+	}
+
+	resp := modifyPlan(immutableFieldPolicyError)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Immutable Post Field Changed", resp.Diagnostics.Errors()[0].Summary())
+	assert.Empty(t, resp.RequiresReplace)
+
+	resp = modifyPlan(immutableFieldPolicyRecreate)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, path.Paths{path.Root("visibility")}, resp.RequiresReplace)
+
+}
+
+func TestPostResource_ImmutableFieldPolicyPlan(t *testing.T) {
+	server := newPostServer(t)
+	defer server.Close()
+
+	post := func(provider string, visibility string, lifecycle string) string {
+		return testUnitProviderConfig(server.URL, provider) + fmt.Sprintf(`
+resource "mastodon_post" "test" {
+  content    = "Hello"
+  visibility = %q
+  %s
+}
+`, visibility, lifecycle)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: post("", "public", ""),
+				Check:  resource.TestCheckResourceAttr("mastodon_post.test", "visibility", "public"),
+			},
+			// The default policy fails the plan.
+			{
+				Config:      post("", "private", ""),
+				ExpectError: regexp.MustCompile("Immutable Post Field Changed"),
+			},
+			// Keeping the current value is left to Terraform.
+			{
+				Config:   post("", "private", "lifecycle {\n    ignore_changes = [visibility]\n  }"),
+				PlanOnly: true,
+			},
+			{
+				Config: post(`immutable_field_policy = "recreate"`, "private", ""),
+				Check:  resource.TestCheckResourceAttr("mastodon_post.test", "visibility", "private"),
+			},
+			// Plans keeping the state value over the configuration are
+			// rejected by Terraform, so there is no policy for it.
+			{
+				Config:      post(`immutable_field_policy = "ignore"`, "public", ""),
+				ExpectError: regexp.MustCompile("value must be one of"),
+			},
+		},
+	})
+}
+
+func TestPostResource_RequireBotAccount(t *testing.T) {
+	modifyPlan := func(user *mastodon.Account) *fwresource.ModifyPlanResponse {
+		r := &PostResource{client: &MastodonClient{requireBotAccount: true, currentUser: user}}
+		config := testPostConfig(t, map[string]tftypes.Value{
+			"content":    tftypes.NewValue(tftypes.String, "Hello"),
+			"visibility": tftypes.NewValue(tftypes.String, "public"),
+		})
+		req := fwresource.ModifyPlanRequest{Config: config, Plan: tfsdk.Plan(config)}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+		return resp
+	}
+
+	resp := modifyPlan(&mastodon.Account{Acct: "tedivm", Bot: false})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Bot Account Required", resp.Diagnostics.Errors()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "@tedivm")
+
+	resp = modifyPlan(&mastodon.Account{Acct: "tedbot", Bot: true})
+	assert.False(t, resp.Diagnostics.HasError())
+}
+
+func TestPostResource_PostTooLong(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uri":"mastodon.example","version":"4.2.0","configuration":{"statuses":{"max_characters":600,"characters_reserved_per_url":23}}}`))
+	}))
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+	post := func(content string, spoilerText string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"content":      tftypes.NewValue(tftypes.String, content),
+			"spoiler_text": tftypes.NewValue(tftypes.String, spoilerText),
+		}
+	}
+	modifyPlan := func(planned map[string]tftypes.Value, prior map[string]tftypes.Value) *fwresource.ModifyPlanResponse {
+		config := testPostConfig(t, planned)
+		req := fwresource.ModifyPlanRequest{
+			Config: config,
+			Plan:   tfsdk.Plan(config),
+			State:  tfsdk.State{Schema: config.Schema, Raw: tftypes.NewValue(config.Raw.Type(), nil)},
+		}
+		if prior != nil {
+			req.State = tfsdk.State(testPostConfig(t, prior))
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+		return resp
+	}
+
+	// Short posts are not checked against the server.
+	resp := modifyPlan(post("Hello", ""), nil)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Zero(t, requests)
+
+	// The server allows more than the default, and the content warning
+	// counts towards it.
+	resp = modifyPlan(post(strings.Repeat("a", 550), ""), nil)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	resp = modifyPlan(post(strings.Repeat("a", 550), strings.Repeat("b", 51)), nil)
+	if assert.True(t, resp.Diagnostics.HasError()) {
+		assert.Equal(t, "Post Too Long", resp.Diagnostics.Errors()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "601 characters long")
+	}
+
+	// Existing posts are only checked when their text changes.
+	long := post(strings.Repeat("a", 700), "")
+	resp = modifyPlan(long, long)
+	assert.False(t, resp.Diagnostics.HasError())
+	resp = modifyPlan(long, post("Hello", ""))
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+func TestPostResource_SpoilerTextIsSensitive(t *testing.T) {
+	modifyPlan := func(sensitive tftypes.Value) (*fwresource.ModifyPlanResponse, PostResourceModel) {
+		r := &PostResource{client: &MastodonClient{}}
+		config := testPostConfig(t, map[string]tftypes.Value{
+			"content":      tftypes.NewValue(tftypes.String, "The butler did it"),
+			"spoiler_text": tftypes.NewValue(tftypes.String, "Spoilers"),
+			"sensitive":    sensitive,
+		})
+		req := fwresource.ModifyPlanRequest{Config: config, Plan: tfsdk.Plan(config)}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+
+		var plan PostResourceModel
+		resp.Diagnostics.Append(resp.Plan.Get(context.Background(), &plan)...)
+		return resp, plan
+	}
+
+	resp, plan := modifyPlan(tftypes.NewValue(tftypes.Bool, nil))
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, plan.Sensitive.ValueBool())
+
+	resp, plan = modifyPlan(tftypes.NewValue(tftypes.Bool, false))
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.False(t, plan.Sensitive.ValueBool())
+	assert.Len(t, resp.Diagnostics.Warnings(), 1)
+	assert.Equal(t, "Post With Content Warning Is Sensitive", resp.Diagnostics.Warnings()[0].Summary())
+}
+
+func TestPostResource_UpgradeStateCreatedAt(t *testing.T) {
+	r := &PostResource{}
+	upgrade := func(createdAt string) string {
+		resp := &fwresource.UpgradeStateResponse{}
+		r.UpgradeState(context.Background())[0].StateUpgrader(context.Background(), fwresource.UpgradeStateRequest{
+			RawState: &tfprotov6.RawState{JSON: []byte(fmt.Sprintf(`{"id":"109372843234","created_at":%q,"content":"Hello","removed":true}`, createdAt))},
+		}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var data PostResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+		assert.Equal(t, "Hello", data.Content.ValueString())
+		return data.CreatedAt.ValueString()
+	}
+
+	assert.Equal(t, "2024-01-02T15:04:05Z", upgrade("2024-01-02 15:04:05 +0000 UTC"))
+	assert.Equal(t, "2024-01-02T15:04:05Z", upgrade("2024-01-02 15:04:05.123 +0000 UTC"))
+	// Values that are already upgraded are kept.
+	assert.Equal(t, "2024-01-02T15:04:05Z", upgrade("2024-01-02T15:04:05Z"))
+}
+
+func TestPostResourceModel_SetStatusEditedAt(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	var data PostResourceModel
+	data.setStatus(&mastodon.Status{ID: "109372843234", CreatedAt: createdAt, Content: "<p>Hello</p>"})
+	assert.Equal(t, "2024-01-02T15:04:05Z", data.CreatedAt.ValueString())
+	assert.True(t, data.EditedAt.IsNull())
+
+	data.setStatus(&mastodon.Status{ID: "109372843234", CreatedAt: createdAt, EditedAt: createdAt.Add(time.Hour), Content: "<p>Hello again</p>"})
+	assert.Equal(t, "2024-01-02T16:04:05Z", data.EditedAt.ValueString())
+}
+
+func TestPostResourceModel_KeepEquivalentContent(t *testing.T) {
+	keep := func(configured string, html string) string {
+		var data PostResourceModel
+		data.setStatus(&mastodon.Status{ID: "109372843234", Content: html})
+		data.keepEquivalentContent(types.StringValue(configured))
+		return data.Content.ValueString()
+	}
+
+	// Text that only changed in the round trip through HTML is kept as
+	// configured.
+	configured := "Hello & welcome  \n\n\n\nsecond line\n"
+	assert.Equal(t, configured, keep(configured, "<p>Hello &amp; welcome</p><p>second line</p>"))
+	configured = "Thanks @tedivm@hachyderm.io!"
+	assert.Equal(t, configured, keep(configured, `<p>Thanks <span class="h-card"><a href="https://hachyderm.io/@tedivm" class="u-url mention">@<span>tedivm</span></a></span>!</p>`))
+
+	// Real changes show up as drift.
+	assert.Equal(t, "Hello & goodbye", keep("Hello & welcome", "<p>Hello &amp; goodbye</p>"))
+	assert.Equal(t, "Hello\nwelcome", keep("Hello welcome", "<p>Hello<br />welcome</p>"))
+}
+
+func TestPostResource_CreateRetried(t *testing.T) {
+	server := newPostServer(t)
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+	create := func(content string, key string) string {
+		values := map[string]tftypes.Value{
+			"content":    tftypes.NewValue(tftypes.String, content),
+			"visibility": tftypes.NewValue(tftypes.String, "public"),
+		}
+		if key != "" {
+			values["idempotency_key"] = tftypes.NewValue(tftypes.String, key)
+		}
+		config := testPostConfig(t, values)
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+		r.Create(context.Background(), fwresource.CreateRequest{Config: config, Plan: tfsdk.Plan(config)}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		var data PostResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+		return data.Id.ValueString()
+	}
+
+	// Applying again after a create whose response was lost is answered
+	// with the post that was already created.
+	first := create("Hello", "")
+	assert.Equal(t, first, create("Hello", ""))
+	assert.NotEqual(t, first, create("Hello again", ""))
+
+	// A key set in the configuration tells identical posts apart.
+	assert.NotEqual(t, first, create("Hello", "second"))
+}
+
+func TestPostResourceModel_IdempotencyKey(t *testing.T) {
+	data := PostResourceModel{
+		Content:     types.StringValue("Hello"),
+		SpoilerText: types.StringNull(),
+		Visibility:  types.StringValue("public"),
+		InReplyToId: types.StringNull(),
+		ScheduledAt: types.StringNull(),
+		Attachments: types.ListNull(types.StringType),
+		MediaIds:    types.ListNull(types.StringType),
+		Poll:        types.ObjectNull(pollAttrTypes),
+	}
+	key, ok := data.idempotencyKey("1")
+	assert.True(t, ok)
+	assert.Len(t, key, 32)
+	same, _ := data.idempotencyKey("1")
+	assert.Equal(t, key, same)
+
+	// Another account posting the same content gets another key.
+	other, _ := data.idempotencyKey("2")
+	assert.NotEqual(t, key, other)
+
+	data.SpoilerText = types.StringValue("Greetings")
+	other, _ = data.idempotencyKey("1")
+	assert.NotEqual(t, key, other)
+
+	// The key is not known while the post is not.
+	data.Content = types.StringUnknown()
+	_, ok = data.idempotencyKey("1")
+	assert.False(t, ok)
+}
+
+func TestPostResource_ModifyPlanIdempotencyKey(t *testing.T) {
+	r := &PostResource{client: &MastodonClient{currentUser: &mastodon.Account{ID: "1"}}}
+	post := func(content string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, "109372843234"),
+			"content":         tftypes.NewValue(tftypes.String, content),
+			"visibility":      tftypes.NewValue(tftypes.String, "public"),
+			"idempotency_key": tftypes.NewValue(tftypes.String, "created-with"),
+		}
+	}
+	modifyPlan := func(content string, state tfsdk.State) PostResourceModel {
+		values := post(content)
+		delete(values, "idempotency_key")
+		config := testPostConfig(t, values)
+		plan := tfsdk.Plan(testPostConfig(t, post(content)))
+		if state.Raw.IsNull() {
+			plan = tfsdk.Plan(config)
+		}
+		req := fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		var data PostResourceModel
+		resp.Diagnostics.Append(resp.Plan.Get(context.Background(), &data)...)
+		return data
+	}
+
+	// A new post is planned with the key derived from it.
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	planned := modifyPlan("Hello", tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)})
+	key, _ := planned.idempotencyKey("1")
+	assert.Equal(t, types.StringValue(key), planned.IdempotencyKey)
+
+	// An existing post keeps its key until the post changes.
+	state := tfsdk.State(testPostConfig(t, post("Hello")))
+	assert.Equal(t, types.StringValue("created-with"), modifyPlan("Hello", state).IdempotencyKey)
+	assert.NotEqual(t, types.StringValue("created-with"), modifyPlan("Hello again", state).IdempotencyKey)
+}
+
+func TestPostResource_ModifyPlanAttachedMedia(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only unattached media can be read.
+		if r.URL.Path != "/api/v1/media/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		currentUser: &mastodon.Account{ID: "1"},
+		mediaCache:  newMediaCache(),
+	}}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	noState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}
+	post := func(mediaIDs ...string) map[string]tftypes.Value {
+		ids := make([]tftypes.Value, 0, len(mediaIDs))
+		for _, id := range mediaIDs {
+			ids = append(ids, tftypes.NewValue(tftypes.String, id))
+		}
+		return map[string]tftypes.Value{
+			"content":   tftypes.NewValue(tftypes.String, "Hello"),
+			"media_ids": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, ids),
+		}
+	}
+	modifyPlan := func(config tfsdk.Config, state tfsdk.State) diag.Diagnostics {
+		req := fwresource.ModifyPlanRequest{Config: config, Plan: tfsdk.Plan(config), State: state}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+		return resp.Diagnostics
+	}
+
+	diags := modifyPlan(testPostConfig(t, post("1")), noState)
+	assert.False(t, diags.HasError(), diags)
+
+	diags = modifyPlan(testPostConfig(t, post("1", "2")), noState)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "Media 2 is attached to another post")
+
+	// Media the post already has is attached to it.
+	state := tfsdk.State(testPostConfig(t, post("2")))
+	diags = modifyPlan(testPostConfig(t, post("2")), state)
+	assert.False(t, diags.HasError(), diags)
+
+	// Without deduplicate_media, media is not checked.
+	r.client.mediaCache = nil
+	diags = modifyPlan(testPostConfig(t, post("2")), noState)
+	assert.False(t, diags.HasError(), diags)
+}
+
+func TestNormalizeStatusText(t *testing.T) {
+	assert.Equal(t, "a b\n\nc", normalizeStatusText(" a \t b \r\n\r\n\r\nc\n"))
+	assert.Equal(t, "hi @tedivm and @me", normalizeStatusText("hi @tedivm@hachyderm.io and @me"))
+}
+
+func TestPostResourceModel_NeedsEdit(t *testing.T) {
+	state := PostResourceModel{
+		Content:     types.StringValue("Hello"),
+		ContentType: types.StringNull(),
+		Visibility:  types.StringValue("public"),
+		Sensitive:   types.BoolValue(false),
+		SpoilerText: types.StringValue(""),
+		Language:    types.StringValue("en"),
+		MediaIds:    types.ListNull(types.StringType),
+		Poll:        types.ObjectNull(pollAttrTypes),
+		Pinned:      types.BoolValue(false),
+	}
+
+	// Pinning does not edit the post.
+	plan := state
+	plan.Pinned = types.BoolValue(true)
+	assert.False(t, plan.needsEdit(state))
+
+	plan.Content = types.StringValue("Hello again")
+	assert.True(t, plan.needsEdit(state))
+}
+
+func TestPostResource_ValidateConfigScheduledPinned(t *testing.T) {
+	config := testPostConfig(t, map[string]tftypes.Value{
+		"content":      tftypes.NewValue(tftypes.String, "Later"),
+		"scheduled_at": tftypes.NewValue(tftypes.String, "2030-05-01T12:00:00Z"),
+		"pinned":       tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	resp := &fwresource.ValidateConfigResponse{}
+	(&PostResource{}).ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Scheduled Post Cannot Be Pinned", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestPostResource_VisibilityValidator(t *testing.T) {
+	var schemaResp fwresource.SchemaResponse
+	(&PostResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	attribute, ok := schemaResp.Schema.Attributes["visibility"].(schema.StringAttribute)
+	assert.True(t, ok)
+
+	validate := func(visibility string) diag.Diagnostics {
+		resp := &validator.StringResponse{}
+		for _, v := range attribute.Validators {
+			v.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("visibility"),
+				ConfigValue: types.StringValue(visibility),
+			}, resp)
+		}
+		return resp.Diagnostics
+	}
+
+	for _, visibility := range postVisibilities {
+		assert.False(t, validate(visibility).HasError(), visibility)
+	}
+	assert.True(t, validate("privat").HasError())
+}
+
+func TestPostResource_ReadCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"7","created_at":"2024-05-01T12:00:00Z","content":"<p>Hello</p>","visibility":"public","account":{"id":"1"}}`))
+	}))
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+	state := tfsdk.State(testPostConfig(t, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "7"),
+		"content": tftypes.NewValue(tftypes.String, "Hello"),
+	}))
+
+	// Requests are made with the context Terraform passes in, so cancelling
+	// it aborts them.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "context canceled")
+}
+
+func TestMatchContentWarning(t *testing.T) {
+	keywords := map[string]string{
+		"spoiler":  "Spoilers",
+		"Election": "Politics",
+	}
+
+	spoilerText, ok := matchContentWarning("The ELECTION results are in", keywords)
+	assert.True(t, ok)
+	assert.Equal(t, "Politics", spoilerText)
+
+	spoilerText, ok = matchContentWarning("Election spoiler ahead", keywords)
+	assert.True(t, ok)
+	assert.Equal(t, "Politics", spoilerText, "first keyword in lexical order wins")
+
+	_, ok = matchContentWarning("Just a nice day outside", keywords)
+	assert.False(t, ok)
+}
+
+func TestPostResource_AutoCWKeepsConfiguredSensitive(t *testing.T) {
+	modifyPlan := func(sensitive tftypes.Value) (*fwresource.ModifyPlanResponse, PostResourceModel) {
+		r := &PostResource{client: &MastodonClient{autoCWKeywords: map[string]string{"election": "Politics"}}}
+		config := testPostConfig(t, map[string]tftypes.Value{
+			"content":    tftypes.NewValue(tftypes.String, "The election results are in"),
+			"visibility": tftypes.NewValue(tftypes.String, "public"),
+			"sensitive":  sensitive,
+		})
+		req := fwresource.ModifyPlanRequest{Config: config, Plan: tfsdk.Plan(config)}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+
+		var plan PostResourceModel
+		resp.Diagnostics.Append(resp.Plan.Get(context.Background(), &plan)...)
+		return resp, plan
+	}
+
+	resp, plan := modifyPlan(tftypes.NewValue(tftypes.Bool, nil))
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "Politics", plan.SpoilerText.ValueString())
+	assert.True(t, plan.Sensitive.ValueBool())
+
+	// Terraform rejects plans that change a configured value, so an
+	// explicit sensitive = false is kept with a warning.
+	resp, plan = modifyPlan(tftypes.NewValue(tftypes.Bool, false))
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "Politics", plan.SpoilerText.ValueString())
+	assert.False(t, plan.Sensitive.ValueBool())
+	if assert.Len(t, resp.Diagnostics.Warnings(), 1) {
+		assert.Equal(t, "Post With Content Warning Is Sensitive", resp.Diagnostics.Warnings()[0].Summary())
+	}
+}
+
+func TestAccPostResource_ImportAndEdit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a post that Terraform will later adopt.
+			{
+				Config: testAccPostResourceConfig("Legacy Post"),
+			},
+			// Import it into state as if it had been created elsewhere.
+			{
+				ResourceName:       "mastodon_post.test",
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			// Editing the adopted post updates it in place.
+			{
+				Config: testAccPostResourceConfig("Legacy Post After Edit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "content", "Legacy Post After Edit"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostResource_InteractionFlags(t *testing.T) {
+	var postID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostResourceConfig("Interaction Flags Post"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureResourceID("mastodon_post.test", &postID),
+					resource.TestCheckResourceAttr("mastodon_post.test", "bookmarked", "false"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "favourited", "false"),
+					resource.TestCheckResourceAttr("mastodon_post.test", "reblogged", "false"),
+				),
+			},
+			// Favourite the post outside of Terraform and confirm the refreshed
+			// state picks it up without proposing changes.
+			{
+				PreConfig: func() {
+					_, err := testAccClient().Favourite(context.Background(), mastodon.ID(postID))
+					assert.NoError(t, err)
+				},
+				Config: testAccPostResourceConfig("Interaction Flags Post"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mastodon_post.test", "favourited", "true"),
+				),
+			},
+		},
+	})
+}
@@ -2,14 +2,23 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mattn/go-mastodon"
@@ -29,12 +38,36 @@ type MastodonProvider struct {
 
 // MastodonProviderModel describes the provider data model.
 type MastodonProviderModel struct {
-	Host         types.String `tfsdk:"host"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	Email        types.String `tfsdk:"email"`
-	Password     types.String `tfsdk:"password"`
-	AccessToken  types.String `tfsdk:"access_token"`
+	Host                 types.String `tfsdk:"host"`
+	ClientID             types.String `tfsdk:"client_id"`
+	ClientSecret         types.String `tfsdk:"client_secret"`
+	Email                types.String `tfsdk:"email"`
+	Password             types.String `tfsdk:"password"`
+	AccessToken          types.String `tfsdk:"access_token"`
+	RetryBudget          types.Int64  `tfsdk:"retry_budget"`
+	MaxRetries           types.Int64  `tfsdk:"max_retries"`
+	RateLimitAware       types.Bool   `tfsdk:"rate_limit_aware"`
+	UserAgent            types.String `tfsdk:"user_agent"`
+	ProxyURL             types.String `tfsdk:"proxy_url"`
+	Insecure             types.Bool   `tfsdk:"insecure"`
+	CACertificate        types.String `tfsdk:"ca_certificate"`
+	CACertificateFile    types.String `tfsdk:"ca_certificate_file"`
+	Timeout              types.Int64  `tfsdk:"timeout"`
+	ValidateOnly         types.Bool   `tfsdk:"validate_only"`
+	AutoCWKeywords       types.Map    `tfsdk:"auto_cw_keywords"`
+	WarnLanguageMismatch types.Bool   `tfsdk:"warn_language_mismatch"`
+	ArchiveOnDestroyPath types.String `tfsdk:"archive_on_destroy_path"`
+	ResolveMentions      types.Bool   `tfsdk:"resolve_mentions"`
+	RedactHandlesInLogs  types.Bool   `tfsdk:"redact_handles_in_logs"`
+	ImportBlocksPath     types.String `tfsdk:"generate_import_blocks_path"`
+	TruncateOverLimit    types.Bool   `tfsdk:"truncate_over_limit"`
+	TruncationSuffix     types.String `tfsdk:"truncation_suffix"`
+	ImmutableFieldPolicy types.String `tfsdk:"immutable_field_policy"`
+	RequireBotAccount    types.Bool   `tfsdk:"require_bot_account"`
+
+	DefaultSensitiveByVisibility types.Map  `tfsdk:"default_sensitive_by_visibility"`
+	DeriveAltFromFilename        types.Bool `tfsdk:"derive_alt_from_filename"`
+	DeduplicateMedia             types.Bool `tfsdk:"deduplicate_media"`
 }
 
 func (p *MastodonProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -72,6 +105,138 @@ func (p *MastodonProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"retry_budget": schema.Int64Attribute{
+				MarkdownDescription: "Total number of retries shared by every request made during a run. Once spent, throttled or failed requests fail immediately instead of retrying. Defaults to `25`. Can be designated by the `MASTODON_RETRY_BUDGET` environment variable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times a single throttled (429) or failed (5xx) request is retried before giving up. Retries wait for the `Retry-After` header when the server sends one, and back off exponentially otherwise. Defaults to `3`. Can be designated by the `MASTODON_MAX_RETRIES` environment variable.",
+				Optional:            true,
+			},
+			"rate_limit_aware": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, requests are held back until the rate limit resets once fewer than 10 requests remain in the current window, instead of running into throttled responses. Each account, including those of resources' `access_token`, has its own rate limits, and media uploads and status deletions are limited separately from other requests, so only requests against the limit that is almost used up are held back. Defaults to `true`. Can be designated by the `MASTODON_RATE_LIMIT_AWARE` environment variable.",
+				Optional:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Number of seconds a single request to the server may take, including reading the response and any retries, before it is abandoned. Waiting for the rate limit to reset does not count toward it. Defaults to `30`. The notification stream of `mastodon_notification_consumer` is bounded by its `timeout_seconds` instead. Can be designated by the `MASTODON_TIMEOUT` environment variable.",
+				Optional:            true,
+			},
+			"user_agent": schema.StringAttribute{
+				MarkdownDescription: "User-Agent header sent with every request, so server admins can identify the traffic. Defaults to `terraform-provider-mastodon/<version>`. Can be designated by the `MASTODON_USER_AGENT` environment variable.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP or SOCKS5 proxy to send requests through, e.g. `http://proxy.example.com:3128` or `socks5://proxy.example.com:1080`. " +
+					"Defaults to the proxy set by the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables. Can be designated by the `MASTODON_PROXY_URL` environment variable.",
+				Optional: true,
+			},
+			"insecure": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, the server's TLS certificate is not verified. Only meant for test instances with a self-signed certificate, as it makes the connection open to interception. " +
+					"Can be designated by the `MASTODON_INSECURE` environment variable.",
+				Optional: true,
+			},
+			"ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificate of a private CA to trust in addition to the system's, for servers with a certificate it signed. " +
+					"Conflicts with `ca_certificate_file`. Can be designated by the `MASTODON_CA_CERTIFICATE` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_certificate_file")),
+				},
+			},
+			"ca_certificate_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with the PEM encoded certificate of a private CA to trust in addition to the system's. " +
+					"Conflicts with `ca_certificate`. Can be designated by the `MASTODON_CA_CERTIFICATE_FILE` environment variable.",
+				Optional: true,
+			},
+			"validate_only": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, resources run their validations and read-only API checks but skip every call that would create, change, or delete anything on the server. " +
+					"Intended for preflight checks in CI: state written in this mode does not reflect real server objects and should be discarded. " +
+					"Can be designated by the `MASTODON_VALIDATE_ONLY` environment variable.",
+				Optional: true,
+			},
+			"auto_cw_keywords": schema.MapAttribute{
+				MarkdownDescription: "Map of keywords to content warnings. When a post without an explicit `spoiler_text` contains one of the keywords (case-insensitive), " +
+					"the mapped content warning is applied and the post is marked sensitive. If several keywords match, the first one in lexical order wins.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"default_sensitive_by_visibility": schema.MapAttribute{
+				MarkdownDescription: "Map of post visibility (`public`, `unlisted`, `private`, or `direct`) to the `sensitive` value used by posts that do not set it, e.g. `{ direct = true }`. " +
+					"Visibilities missing from the map keep the default of `false`.",
+				ElementType: types.BoolType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(postVisibilities...)),
+				},
+			},
+			"warn_language_mismatch": schema.BoolAttribute{
+				MarkdownDescription: "Warn during planning when a post's `language` does not plausibly match the script its content is written in, e.g. Latin text tagged `ja`. " +
+					"Can be designated by the `MASTODON_WARN_LANGUAGE_MISMATCH` environment variable.",
+				Optional: true,
+			},
+			"archive_on_destroy_path": schema.StringAttribute{
+				MarkdownDescription: "Path of a JSON Lines file that the source text and metadata (`id`, `created_at`, `content`, ...) of every destroyed post are appended to before it is deleted. " +
+					"Can be designated by the `MASTODON_ARCHIVE_ON_DESTROY_PATH` environment variable.",
+				Optional: true,
+			},
+			"generate_import_blocks_path": schema.StringAttribute{
+				MarkdownDescription: "Path of a file that a Terraform `import` block is appended to for every post destroyed with `preserve_on_destroy`, so the post can be adopted again by a later configuration. " +
+					"Terraform does not tell providers the address of the resource being destroyed, so the blocks import into a resource named after the post's ID, which can be renamed as needed. " +
+					"Can be designated by the `MASTODON_GENERATE_IMPORT_BLOCKS_PATH` environment variable.",
+				Optional: true,
+			},
+			"resolve_mentions": schema.BoolAttribute{
+				MarkdownDescription: "Resolve every `@user@domain` mention in a post's content through the server's search before posting, so mentions of remote accounts the server has not seen yet are delivered. " +
+					"Mentions that cannot be resolved produce a warning. Defaults to `false`. " +
+					"Can be designated by the `MASTODON_RESOLVE_MENTIONS` environment variable.",
+				Optional: true,
+			},
+			"redact_handles_in_logs": schema.BoolAttribute{
+				MarkdownDescription: "Replace account handles in the provider's log output with a hash. The same handle always maps to the same hash within a run, so log lines can still be correlated. Defaults to `false`. " +
+					"Can be designated by the `MASTODON_REDACT_HANDLES_IN_LOGS` environment variable.",
+				Optional: true,
+			},
+			"truncate_over_limit": schema.BoolAttribute{
+				MarkdownDescription: "Truncate post content longer than the server's limit on a word boundary and append `truncation_suffix`, instead of letting the server reject the post. " +
+					"Length is counted the way Mastodon counts it: links count as the server's reserved length and remote mentions count without their domain. " +
+					"Truncated posts produce a warning at plan time. Defaults to `false`. " +
+					"Can be designated by the `MASTODON_TRUNCATE_OVER_LIMIT` environment variable.",
+				Optional: true,
+			},
+			"truncation_suffix": schema.StringAttribute{
+				MarkdownDescription: "The text appended to content truncated by `truncate_over_limit`. Defaults to `…`. " +
+					"Can be designated by the `MASTODON_TRUNCATION_SUFFIX` environment variable.",
+				Optional: true,
+			},
+			"immutable_field_policy": schema.StringAttribute{
+				MarkdownDescription: "What to do when a plan changes a field Mastodon cannot edit on an existing post, such as `visibility` or `attachments`: " +
+					"`error` fails the plan and `recreate` deletes the post and posts it again. Defaults to `error`. " +
+					"To keep the existing value instead, add the field to the resource's `lifecycle` `ignore_changes`. " +
+					"Can be designated by the `MASTODON_IMMUTABLE_FIELD_POLICY` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(immutableFieldPolicies...),
+				},
+			},
+			"require_bot_account": schema.BoolAttribute{
+				MarkdownDescription: "Fail the plan of any post made from an account that is not flagged as a bot, as many instances require of automated accounts. " +
+					"Posts made with a resource's `access_token` are checked against that account instead. Defaults to `false`. " +
+					"Can be designated by the `MASTODON_REQUIRE_BOT_ACCOUNT` environment variable.",
+				Optional: true,
+			},
+			"derive_alt_from_filename": schema.BoolAttribute{
+				MarkdownDescription: "Use a readable form of the file name as the alt text of `mastodon_media` uploaded from a `file` without a `description`, e.g. `my cat photo` for `my_cat_photo.jpg`. " +
+					"An explicit `description` is always kept. Defaults to `false`. " +
+					"Can be designated by the `MASTODON_DERIVE_ALT_FROM_FILENAME` environment variable.",
+				Optional: true,
+			},
+			"deduplicate_media": schema.BoolAttribute{
+				MarkdownDescription: "Upload media with the same content, `description` and `focus` only once per run, so every `mastodon_media` with identical media gets the same `id`. " +
+					"Mastodon attaches media to a single post, so only one `mastodon_post` can use a deduplicated `id`; a second post with it fails to plan or apply. " +
+					"Destroying a `mastodon_media` leaves its media to the server, which removes unattached media on its own, since other resources may share it. Defaults to `false`. " +
+					"Can be designated by the `MASTODON_DEDUPLICATE_MEDIA` environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -180,6 +345,545 @@ func (p *MastodonProvider) Configure(ctx context.Context, req provider.Configure
 		access_token = data.AccessToken.ValueString()
 	}
 
+	if data.RetryBudget.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_budget"),
+			"Unknown Mastodon Retry Budget",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for the retry budget. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_RETRY_BUDGET environment variable.",
+		)
+	}
+	retry_budget := int64(defaultRetryBudget)
+	if v := os.Getenv("MASTODON_RETRY_BUDGET"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_budget"),
+				"Invalid Mastodon Retry Budget",
+				"The MASTODON_RETRY_BUDGET environment variable must be a whole number: "+err.Error(),
+			)
+		}
+		retry_budget = parsed
+	}
+	if !data.RetryBudget.IsNull() {
+		retry_budget = data.RetryBudget.ValueInt64()
+	}
+	if retry_budget < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_budget"),
+			"Invalid Mastodon Retry Budget",
+			"The retry budget cannot be negative.",
+		)
+	}
+
+	if data.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Mastodon Max Retries",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for max_retries. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_MAX_RETRIES environment variable.",
+		)
+	}
+	max_retries := int64(defaultMaxRetries)
+	if v := os.Getenv("MASTODON_MAX_RETRIES"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Mastodon Max Retries",
+				"The MASTODON_MAX_RETRIES environment variable must be a whole number: "+err.Error(),
+			)
+		}
+		max_retries = parsed
+	}
+	if !data.MaxRetries.IsNull() {
+		max_retries = data.MaxRetries.ValueInt64()
+	}
+	if max_retries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Mastodon Max Retries",
+			"The maximum number of retries cannot be negative.",
+		)
+	}
+
+	if data.RateLimitAware.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rate_limit_aware"),
+			"Unknown Mastodon Rate Limit Awareness",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for rate_limit_aware. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_RATE_LIMIT_AWARE environment variable.",
+		)
+	}
+	rate_limit_aware := true
+	if v := os.Getenv("MASTODON_RATE_LIMIT_AWARE"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rate_limit_aware"),
+				"Invalid Mastodon Rate Limit Awareness",
+				"The MASTODON_RATE_LIMIT_AWARE environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		rate_limit_aware = parsed
+	}
+	if !data.RateLimitAware.IsNull() {
+		rate_limit_aware = data.RateLimitAware.ValueBool()
+	}
+
+	if data.Timeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Unknown Mastodon Timeout",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for the timeout. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_TIMEOUT environment variable.",
+		)
+	}
+	timeout := int64(defaultTimeout / time.Second)
+	if v := os.Getenv("MASTODON_TIMEOUT"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid Mastodon Timeout",
+				"The MASTODON_TIMEOUT environment variable must be a whole number of seconds: "+err.Error(),
+			)
+		}
+		timeout = parsed
+	}
+	if !data.Timeout.IsNull() {
+		timeout = data.Timeout.ValueInt64()
+	}
+	if timeout <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Invalid Mastodon Timeout",
+			"The timeout must be a positive number of seconds.",
+		)
+	}
+
+	if data.UserAgent.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent"),
+			"Unknown Mastodon User Agent",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for user_agent. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_USER_AGENT environment variable.",
+		)
+	}
+	user_agent := "terraform-provider-mastodon/" + p.version
+	if v := os.Getenv("MASTODON_USER_AGENT"); v != "" {
+		user_agent = v
+	}
+	if !data.UserAgent.IsNull() {
+		user_agent = data.UserAgent.ValueString()
+	}
+
+	if data.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown Mastodon Proxy URL",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for proxy_url. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_PROXY_URL environment variable.",
+		)
+	}
+	proxy_url := os.Getenv("MASTODON_PROXY_URL")
+	if !data.ProxyURL.IsNull() {
+		proxy_url = data.ProxyURL.ValueString()
+	}
+	var proxy *url.URL
+	if proxy_url != "" {
+		var err error
+		proxy, err = parseProxyURL(proxy_url)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Mastodon Proxy URL",
+				"The proxy URL is not valid: "+err.Error(),
+			)
+		}
+	}
+
+	if data.Insecure.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure"),
+			"Unknown Mastodon Insecure Mode",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for insecure. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_INSECURE environment variable.",
+		)
+	}
+	insecure := false
+	if v := os.Getenv("MASTODON_INSECURE"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure"),
+				"Invalid Mastodon Insecure Mode",
+				"The MASTODON_INSECURE environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		insecure = parsed
+	}
+	if !data.Insecure.IsNull() {
+		insecure = data.Insecure.ValueBool()
+	}
+	if insecure {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure"),
+			"Mastodon TLS Verification Disabled",
+			"The server's TLS certificate is not verified, so the connection, including the credentials sent over it, can be intercepted. "+
+				"Only use insecure with test instances.",
+		)
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecure}
+
+	if data.CACertificate.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate"),
+			"Unknown Mastodon CA Certificate",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for ca_certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_CA_CERTIFICATE environment variable.",
+		)
+	}
+	if data.CACertificateFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate_file"),
+			"Unknown Mastodon CA Certificate File",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for ca_certificate_file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_CA_CERTIFICATE_FILE environment variable.",
+		)
+	}
+	ca_certificate := os.Getenv("MASTODON_CA_CERTIFICATE")
+	ca_certificate_file := os.Getenv("MASTODON_CA_CERTIFICATE_FILE")
+	if !data.CACertificate.IsNull() {
+		ca_certificate = data.CACertificate.ValueString()
+		ca_certificate_file = ""
+	}
+	if !data.CACertificateFile.IsNull() {
+		ca_certificate_file = data.CACertificateFile.ValueString()
+		ca_certificate = ""
+	}
+	ca_certificate_path := path.Root("ca_certificate")
+	if ca_certificate_file != "" {
+		ca_certificate_path = path.Root("ca_certificate_file")
+		if ca_certificate != "" {
+			resp.Diagnostics.AddAttributeError(
+				ca_certificate_path,
+				"Conflicting Mastodon CA Certificates",
+				"Only one of the MASTODON_CA_CERTIFICATE and MASTODON_CA_CERTIFICATE_FILE environment variables can be set.",
+			)
+		}
+		contents, err := os.ReadFile(ca_certificate_file)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				ca_certificate_path,
+				"Unable to Read Mastodon CA Certificate",
+				"The CA certificate file could not be read: "+err.Error(),
+			)
+		}
+		ca_certificate = string(contents)
+	}
+	if ca_certificate != "" {
+		pool, err := caCertPool([]byte(ca_certificate))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				ca_certificate_path,
+				"Invalid Mastodon CA Certificate",
+				"The CA certificate must be one or more PEM encoded certificates, starting with -----BEGIN CERTIFICATE-----: "+err.Error(),
+			)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if data.ValidateOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validate_only"),
+			"Unknown Mastodon Validate Only Mode",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for validate_only. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_VALIDATE_ONLY environment variable.",
+		)
+	}
+	validate_only := false
+	if v := os.Getenv("MASTODON_VALIDATE_ONLY"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validate_only"),
+				"Invalid Mastodon Validate Only Mode",
+				"The MASTODON_VALIDATE_ONLY environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		validate_only = parsed
+	}
+	if !data.ValidateOnly.IsNull() {
+		validate_only = data.ValidateOnly.ValueBool()
+	}
+	if validate_only {
+		resp.Diagnostics.AddWarning(
+			"Mastodon Provider Running in Validate Only Mode",
+			"No posts or other objects will be created, changed, or deleted on the server. "+
+				"Terraform state produced in this mode does not reflect real server objects.",
+		)
+	}
+
+	if data.AutoCWKeywords.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auto_cw_keywords"),
+			"Unknown Mastodon Content Warning Keywords",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for auto_cw_keywords. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	auto_cw_keywords := map[string]string{}
+	if !data.AutoCWKeywords.IsNull() && !data.AutoCWKeywords.IsUnknown() {
+		resp.Diagnostics.Append(data.AutoCWKeywords.ElementsAs(ctx, &auto_cw_keywords, false)...)
+	}
+
+	if data.DefaultSensitiveByVisibility.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_sensitive_by_visibility"),
+			"Unknown Mastodon Default Sensitivity",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for default_sensitive_by_visibility. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	default_sensitive_by_visibility := map[string]bool{}
+	if !data.DefaultSensitiveByVisibility.IsNull() && !data.DefaultSensitiveByVisibility.IsUnknown() {
+		resp.Diagnostics.Append(data.DefaultSensitiveByVisibility.ElementsAs(ctx, &default_sensitive_by_visibility, false)...)
+	}
+
+	if data.WarnLanguageMismatch.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("warn_language_mismatch"),
+			"Unknown Mastodon Language Mismatch Warning",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for warn_language_mismatch. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_WARN_LANGUAGE_MISMATCH environment variable.",
+		)
+	}
+	warn_language_mismatch := false
+	if v := os.Getenv("MASTODON_WARN_LANGUAGE_MISMATCH"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("warn_language_mismatch"),
+				"Invalid Mastodon Language Mismatch Warning",
+				"The MASTODON_WARN_LANGUAGE_MISMATCH environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		warn_language_mismatch = parsed
+	}
+	if !data.WarnLanguageMismatch.IsNull() {
+		warn_language_mismatch = data.WarnLanguageMismatch.ValueBool()
+	}
+
+	if data.ArchiveOnDestroyPath.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("archive_on_destroy_path"),
+			"Unknown Mastodon Archive Path",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for archive_on_destroy_path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_ARCHIVE_ON_DESTROY_PATH environment variable.",
+		)
+	}
+	archive_on_destroy_path := os.Getenv("MASTODON_ARCHIVE_ON_DESTROY_PATH")
+	if !data.ArchiveOnDestroyPath.IsNull() {
+		archive_on_destroy_path = data.ArchiveOnDestroyPath.ValueString()
+	}
+
+	if data.ImportBlocksPath.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("generate_import_blocks_path"),
+			"Unknown Mastodon Import Blocks Path",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for generate_import_blocks_path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_GENERATE_IMPORT_BLOCKS_PATH environment variable.",
+		)
+	}
+	generate_import_blocks_path := os.Getenv("MASTODON_GENERATE_IMPORT_BLOCKS_PATH")
+	if !data.ImportBlocksPath.IsNull() {
+		generate_import_blocks_path = data.ImportBlocksPath.ValueString()
+	}
+
+	if data.ResolveMentions.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resolve_mentions"),
+			"Unknown Mastodon Mention Resolution",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for resolve_mentions. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_RESOLVE_MENTIONS environment variable.",
+		)
+	}
+	resolve_mentions := false
+	if v := os.Getenv("MASTODON_RESOLVE_MENTIONS"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("resolve_mentions"),
+				"Invalid Mastodon Mention Resolution",
+				"The MASTODON_RESOLVE_MENTIONS environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		resolve_mentions = parsed
+	}
+	if !data.ResolveMentions.IsNull() {
+		resolve_mentions = data.ResolveMentions.ValueBool()
+	}
+
+	if data.RedactHandlesInLogs.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("redact_handles_in_logs"),
+			"Unknown Mastodon Log Redaction",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for redact_handles_in_logs. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_REDACT_HANDLES_IN_LOGS environment variable.",
+		)
+	}
+	redact_handles_in_logs := false
+	if v := os.Getenv("MASTODON_REDACT_HANDLES_IN_LOGS"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("redact_handles_in_logs"),
+				"Invalid Mastodon Log Redaction",
+				"The MASTODON_REDACT_HANDLES_IN_LOGS environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		redact_handles_in_logs = parsed
+	}
+	if !data.RedactHandlesInLogs.IsNull() {
+		redact_handles_in_logs = data.RedactHandlesInLogs.ValueBool()
+	}
+
+	if data.TruncateOverLimit.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("truncate_over_limit"),
+			"Unknown Mastodon Truncation",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for truncate_over_limit. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_TRUNCATE_OVER_LIMIT environment variable.",
+		)
+	}
+	truncate_over_limit := false
+	if v := os.Getenv("MASTODON_TRUNCATE_OVER_LIMIT"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("truncate_over_limit"),
+				"Invalid Mastodon Truncation",
+				"The MASTODON_TRUNCATE_OVER_LIMIT environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		truncate_over_limit = parsed
+	}
+	if !data.TruncateOverLimit.IsNull() {
+		truncate_over_limit = data.TruncateOverLimit.ValueBool()
+	}
+
+	if data.TruncationSuffix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("truncation_suffix"),
+			"Unknown Mastodon Truncation Suffix",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for truncation_suffix. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_TRUNCATION_SUFFIX environment variable.",
+		)
+	}
+	truncation_suffix := "…"
+	if v, ok := os.LookupEnv("MASTODON_TRUNCATION_SUFFIX"); ok {
+		truncation_suffix = v
+	}
+	if !data.TruncationSuffix.IsNull() {
+		truncation_suffix = data.TruncationSuffix.ValueString()
+	}
+
+	if data.ImmutableFieldPolicy.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("immutable_field_policy"),
+			"Unknown Mastodon Immutable Field Policy",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for immutable_field_policy. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_IMMUTABLE_FIELD_POLICY environment variable.",
+		)
+	}
+	immutable_field_policy := immutableFieldPolicyError
+	if v := os.Getenv("MASTODON_IMMUTABLE_FIELD_POLICY"); v != "" {
+		if !slices.Contains(immutableFieldPolicies, v) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("immutable_field_policy"),
+				"Invalid Mastodon Immutable Field Policy",
+				fmt.Sprintf("The MASTODON_IMMUTABLE_FIELD_POLICY environment variable must be one of %v, got %q.", immutableFieldPolicies, v),
+			)
+		}
+		immutable_field_policy = v
+	}
+	if !data.ImmutableFieldPolicy.IsNull() {
+		immutable_field_policy = data.ImmutableFieldPolicy.ValueString()
+	}
+
+	if data.RequireBotAccount.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("require_bot_account"),
+			"Unknown Mastodon Bot Account Requirement",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for require_bot_account. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_REQUIRE_BOT_ACCOUNT environment variable.",
+		)
+	}
+	require_bot_account := false
+	if v := os.Getenv("MASTODON_REQUIRE_BOT_ACCOUNT"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("require_bot_account"),
+				"Invalid Mastodon Bot Account Requirement",
+				"The MASTODON_REQUIRE_BOT_ACCOUNT environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		require_bot_account = parsed
+	}
+	if !data.RequireBotAccount.IsNull() {
+		require_bot_account = data.RequireBotAccount.ValueBool()
+	}
+
+	if data.DeriveAltFromFilename.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("derive_alt_from_filename"),
+			"Unknown Mastodon Alt Text Derivation",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for derive_alt_from_filename. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_DERIVE_ALT_FROM_FILENAME environment variable.",
+		)
+	}
+	derive_alt_from_filename := false
+	if v := os.Getenv("MASTODON_DERIVE_ALT_FROM_FILENAME"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("derive_alt_from_filename"),
+				"Invalid Mastodon Alt Text Derivation",
+				"The MASTODON_DERIVE_ALT_FROM_FILENAME environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		derive_alt_from_filename = parsed
+	}
+	if !data.DeriveAltFromFilename.IsNull() {
+		derive_alt_from_filename = data.DeriveAltFromFilename.ValueBool()
+	}
+
+	if data.DeduplicateMedia.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deduplicate_media"),
+			"Unknown Mastodon Media Deduplication",
+			"The provider cannot create the Mastodon API client as there is an unknown configuration value for deduplicate_media. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MASTODON_DEDUPLICATE_MEDIA environment variable.",
+		)
+	}
+	deduplicate_media := false
+	if v := os.Getenv("MASTODON_DEDUPLICATE_MEDIA"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deduplicate_media"),
+				"Invalid Mastodon Media Deduplication",
+				"The MASTODON_DEDUPLICATE_MEDIA environment variable must be a boolean: "+err.Error(),
+			)
+		}
+		deduplicate_media = parsed
+	}
+	if !data.DeduplicateMedia.IsNull() {
+		deduplicate_media = data.DeduplicateMedia.ValueBool()
+	}
+
 	if access_token == "" && (user_email == "" || user_password == "") {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("user-access-token"),
@@ -211,7 +915,24 @@ func (p *MastodonProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	c := mastodon.NewClient(&config)
-	user, err := c.GetAccountCurrentUser(context.Background())
+	c.UserAgent = user_agent
+	rateLimits := &rateLimitTracker{}
+	// The timeout is applied by the transport rather than by c.Timeout, so
+	// waiting for the rate limit to reset does not use it up.
+	retries := newRetryTransport(newHTTPTransport(proxy, tlsConfig), newRetryBudget(retry_budget), int(max_retries))
+	c.Transport = newAPIErrorTransport(newRateLimitTransport(newTimeoutTransport(retries, time.Duration(timeout)*time.Second), rateLimits, rate_limit_aware))
+	if access_token == "" {
+		// Exchange the email and password for an access token, which the
+		// client sends with every request from then on.
+		if err := c.Authenticate(ctx, user_email, user_password); err != nil {
+			resp.Diagnostics.AddError(
+				"Mastodon Authentication Failed",
+				"The provider could not log in with the configured email and password: "+err.Error(),
+			)
+			return
+		}
+	}
+	user, err := c.GetAccountCurrentUser(ctx)
 	if err != nil {
 		tflog.Error(ctx, "GetAccountCurrentUser Error: "+err.Error())
 		resp.Diagnostics.AddError(
@@ -220,8 +941,6 @@ func (p *MastodonProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
-	tflog.Debug(ctx, "mastodon_provider current user: "+user.Acct)
-
 	if access_token != "" {
 		ctx = tflog.SetField(ctx, "mastodon_access_token", access_token)
 		tflog.MaskFieldValuesWithFieldKeys(ctx, "mastodon_access_token")
@@ -241,26 +960,92 @@ func (p *MastodonProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	// Objects handed to this configuration must live on this host.
+	server_host := host
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		server_host = u.Host
+	}
+
+	client := &MastodonClient{
+		Client:                       c,
+		host:                         server_host,
+		currentUser:                  user,
+		validateOnly:                 validate_only,
+		autoCWKeywords:               auto_cw_keywords,
+		defaultSensitiveByVisibility: default_sensitive_by_visibility,
+		warnLanguageMismatch:         warn_language_mismatch,
+		resolveMentions:              resolve_mentions,
+		truncateOverLimit:            truncate_over_limit,
+		truncationSuffix:             truncation_suffix,
+		immutableFieldPolicy:         immutable_field_policy,
+		requireBotAccount:            require_bot_account,
+		deriveAltFromFilename:        derive_alt_from_filename,
+	}
+	if archive_on_destroy_path != "" {
+		client.archive = newPostArchive(archive_on_destroy_path)
+	}
+	if generate_import_blocks_path != "" {
+		client.importBlocks = newImportBlockWriter(generate_import_blocks_path)
+	}
+	if deduplicate_media {
+		client.mediaCache = newMediaCache()
+	}
+	if redact_handles_in_logs {
+		client.redactor, err = newHandleRedactor()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Configure Log Redaction",
+				"The provider could not generate the salt used to hash account handles: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "mastodon_provider current user: "+client.logHandle(user.Acct))
+
 	// Example client configuration for data sources and resources
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	resp.DataSourceData = client
+	resp.ResourceData = client
 }
 
 func (p *MastodonProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewPostResource,
+		NewStatusReactionResource,
+		NewBookmarkResource,
+		NewFavouriteResource,
+		NewBoostResource,
+		NewNotificationConsumerResource,
+		NewListMembershipResource,
+		NewListMemberResource,
+		NewMediaResource,
+		NewFollowResource,
+		NewApplicationResource,
+		NewBlockResource,
 	}
 }
 
 func (p *MastodonProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAccessTokenDataSource,
 		NewAccountDataSource,
+		NewFollowRequestsDataSource,
+		NewInstanceDataSource,
+		NewNotificationsDataSource,
+		NewRateLimitDataSource,
+		NewRelationshipDataSource,
+		NewResolvedIdentityDataSource,
+		NewSearchDataSource,
+		NewStatusDataSource,
+		NewTimelineDataSource,
+		NewTrendsDataSource,
 	}
 }
 
 func (p *MastodonProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
 		NewIdentityFunction,
+		NewDurationSecondsFunction,
 	}
 }
 
@@ -1,11 +1,15 @@
 package provider
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mattn/go-mastodon"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,3 +34,27 @@ func testAccPreCheck(t *testing.T) {
 	client_token := os.Getenv("MASTODON_ACCESS_TOKEN")
 	assert.NotEmpty(t, client_token, "MASTODON_ACCESS_TOKEN must be set for acceptance tests")
 }
+
+// testAccClient returns an API client configured from the acceptance test
+// environment, used to arrange server state outside of Terraform.
+func testAccClient() *mastodon.Client {
+	return mastodon.NewClient(&mastodon.Config{
+		Server:       os.Getenv("MASTODON_HOST"),
+		ClientID:     os.Getenv("MASTODON_CLIENT_ID"),
+		ClientSecret: os.Getenv("MASTODON_CLIENT_SECRET"),
+		AccessToken:  os.Getenv("MASTODON_ACCESS_TOKEN"),
+	})
+}
+
+// testAccCaptureResourceID stores the ID of a resource so later test steps
+// can act on it.
+func testAccCaptureResourceID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// rateLimitReserve is the number of remaining requests below which a rate
+// limit aware transport waits for the limit to reset. It matches Terraform's
+// default parallelism, so concurrent requests do not use up the last ones.
+const rateLimitReserve = 10
+
+// rateLimit is the rate limit state the server reported in the headers of a
+// response.
+type rateLimit struct {
+	Limit     int64
+	Remaining int64
+	Reset     time.Time
+}
+
+// parseRateLimit reads the X-RateLimit-* headers, reporting false when any of
+// them is missing or malformed.
+func parseRateLimit(header http.Header) (rateLimit, bool) {
+	limit, err := strconv.ParseInt(header.Get("X-RateLimit-Limit"), 10, 64)
+	if err != nil {
+		return rateLimit{}, false
+	}
+	remaining, err := strconv.ParseInt(header.Get("X-RateLimit-Remaining"), 10, 64)
+	if err != nil {
+		return rateLimit{}, false
+	}
+	reset, err := time.Parse(time.RFC3339Nano, header.Get("X-RateLimit-Reset"))
+	if err != nil {
+		return rateLimit{}, false
+	}
+	return rateLimit{Limit: limit, Remaining: remaining, Reset: reset}, true
+}
+
+// rateLimitBucket identifies a rate limit. Mastodon keeps its limits per
+// account, and limits media uploads and status deletions separately from, and
+// far more tightly than, all other requests.
+type rateLimitBucket struct {
+	// account is the access token of the request, which is empty for
+	// unauthenticated requests.
+	account string
+	family  string
+}
+
+const (
+	rateLimitFamilyDefault       = "default"
+	rateLimitFamilyMedia         = "media"
+	rateLimitFamilyStatusDeletes = "status_deletes"
+)
+
+// rateLimitBucketOf returns the rate limit the request counts against.
+func rateLimitBucketOf(req *http.Request) rateLimitBucket {
+	token := strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer"))
+	bucket := rateLimitBucket{account: token, family: rateLimitFamilyDefault}
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case req.Method == http.MethodPost && len(segments) == 3 && segments[0] == "api" && segments[2] == "media":
+		bucket.family = rateLimitFamilyMedia
+	case len(segments) >= 4 && segments[0] == "api" && segments[1] == "v1" && segments[2] == "statuses" &&
+		(req.Method == http.MethodDelete && len(segments) == 4 ||
+			req.Method == http.MethodPost && len(segments) == 5 && segments[4] == "unreblog"):
+		bucket.family = rateLimitFamilyStatusDeletes
+	}
+	return bucket
+}
+
+// rateLimitTracker remembers, for every rate limit, the state reported by the
+// most recent response that carried one.
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	limits map[rateLimitBucket]rateLimit
+}
+
+func (t *rateLimitTracker) observe(bucket rateLimitBucket, header http.Header) {
+	limit, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.limits == nil {
+		t.limits = map[rateLimitBucket]rateLimit{}
+	}
+	t.limits[bucket] = limit
+}
+
+// current returns the last observed state of the rate limit, reporting false
+// when no response has carried rate limit headers for it yet.
+func (t *rateLimitTracker) current(bucket rateLimitBucket) (rateLimit, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limit, ok := t.limits[bucket]
+	return limit, ok
+}
+
+// delay returns how long to wait before the next request against the rate
+// limit, which is until it resets once fewer than rateLimitReserve requests
+// remain.
+func (t *rateLimitTracker) delay(bucket rateLimitBucket, now time.Time) time.Duration {
+	limit, ok := t.current(bucket)
+	if !ok || limit.Remaining >= rateLimitReserve || !limit.Reset.After(now) {
+		return 0
+	}
+	return limit.Reset.Sub(now)
+}
+
+// rateLimitTransport feeds the headers of every response to a tracker. When
+// throttle is set, it also holds requests back while the rate limit they
+// count against is close to being used up.
+//
+// The transport is shared by the clients of every access token, so it tells
+// the rate limits apart by the request rather than by the client.
+type rateLimitTransport struct {
+	base     http.RoundTripper
+	tracker  *rateLimitTracker
+	throttle bool
+}
+
+func newRateLimitTransport(base http.RoundTripper, tracker *rateLimitTracker, throttle bool) *rateLimitTransport {
+	return &rateLimitTransport{base: base, tracker: tracker, throttle: throttle}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bucket := rateLimitBucketOf(req)
+	if t.throttle {
+		if err := t.wait(req, bucket); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.tracker.observe(bucket, resp.Header)
+	}
+	return resp, err
+}
+
+// wait blocks until the rate limit resets when few requests remain. The
+// provider's request timeout only starts once the wait is over, so only a
+// deadline on the context of the request itself skips waits that would
+// outlast it, leaving the request to the retries of throttled responses.
+func (t *rateLimitTransport) wait(req *http.Request, bucket rateLimitBucket) error {
+	delay := t.tracker.delay(bucket, time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	ctx := req.Context()
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("rate limit almost used up, waiting %s for it to reset", delay.Round(time.Second)))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RateLimitDataSource{}
+
+func NewRateLimitDataSource() datasource.DataSource {
+	return &RateLimitDataSource{}
+}
+
+// RateLimitDataSource defines the data source implementation.
+type RateLimitDataSource struct {
+	client *MastodonClient
+}
+
+// RateLimitDataSourceModel describes the data source data model.
+type RateLimitDataSourceModel struct {
+	Limit     types.Int64  `tfsdk:"limit"`
+	Remaining types.Int64  `tfsdk:"remaining"`
+	Reset     types.String `tfsdk:"reset"`
+}
+
+func (d *RateLimitDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rate_limit"
+}
+
+func (d *RateLimitDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads the API rate limit of the authenticated account. It makes one lightweight request and reports the rate limit headers of the response.",
+
+		Attributes: map[string]schema.Attribute{
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests allowed in the current window.",
+				Computed:            true,
+			},
+			"remaining": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests left in the current window.",
+				Computed:            true,
+			},
+			"reset": schema.StringAttribute{
+				MarkdownDescription: "When the current window ends and `remaining` is reset to `limit`, as an RFC3339 timestamp.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RateLimitDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RateLimitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RateLimitDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_rate_limit data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Any authenticated request reports the rate limit; verifying the
+	// credentials is one of the cheapest. The headers of this response are
+	// read rather than those the transport last saw, which may belong to
+	// another account or to the separate limits of uploads and deletions.
+	header, err := d.client.doAPIWithHeader(ctx, http.MethodGet, "/api/v1/accounts/verify_credentials", nil, nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read rate limit",
+			fmt.Sprintf("Failed to read rate limit: %s", err),
+		)
+		return
+	}
+
+	limit, ok := parseRateLimit(header)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Failed to read rate limit",
+			"The server did not report a rate limit. It may not send the X-RateLimit-* headers, or they may be stripped by a proxy.",
+		)
+		return
+	}
+
+	data.Limit = types.Int64Value(limit.Limit)
+	data.Remaining = types.Int64Value(limit.Remaining)
+	data.Reset = types.StringValue(limit.Reset.UTC().Format(time.RFC3339))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_rate_limit data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
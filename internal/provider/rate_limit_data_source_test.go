@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccRateLimitDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccRateLimitDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_rate_limit.test", "limit"),
+					resource.TestCheckResourceAttrSet("data.mastodon_rate_limit.test", "remaining"),
+					resource.TestCheckResourceAttrSet("data.mastodon_rate_limit.test", "reset"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRateLimitDataSourceConfig = `
+data "mastodon_rate_limit" "test" {}
+`
+
+func TestRateLimitDataSource_Read(t *testing.T) {
+	withHeaders := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if withHeaders && r.URL.Path == "/api/v1/accounts/verify_credentials" {
+			w.Header().Set("X-RateLimit-Limit", "300")
+			w.Header().Set("X-RateLimit-Remaining", "297")
+			w.Header().Set("X-RateLimit-Reset", "2024-05-06T12:35:00.123Z")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	d := &RateLimitDataSource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL, AccessToken: "token"})}}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	read := func() *datasource.ReadResponse {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		diags := config.Set(context.Background(), &RateLimitDataSourceModel{
+			Limit:     types.Int64Null(),
+			Remaining: types.Int64Null(),
+			Reset:     types.StringNull(),
+		})
+		assert.False(t, diags.HasError(), diags)
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp
+	}
+
+	resp := read()
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var data RateLimitDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	assert.Equal(t, types.Int64Value(300), data.Limit)
+	assert.Equal(t, types.Int64Value(297), data.Remaining)
+	assert.Equal(t, types.StringValue("2024-05-06T12:35:00Z"), data.Reset)
+
+	// The rate limit is read from the response itself, so a response
+	// without the headers fails rather than reporting an earlier one.
+	withHeaders = false
+	resp = read()
+	assert.True(t, resp.Diagnostics.HasError())
+}
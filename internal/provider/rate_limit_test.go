@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/accounts/verify_credentials" {
+			w.Header().Set("X-RateLimit-Limit", "300")
+			w.Header().Set("X-RateLimit-Remaining", "297")
+			w.Header().Set("X-RateLimit-Reset", "2024-05-06T12:35:00.123Z")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tracker := &rateLimitTracker{}
+	c := mastodon.NewClient(&mastodon.Config{Server: server.URL})
+	c.Transport = newRateLimitTransport(http.DefaultTransport, tracker, false)
+	client := &MastodonClient{Client: c}
+
+	bucket := rateLimitBucket{family: rateLimitFamilyDefault}
+	_, ok := tracker.current(bucket)
+	assert.False(t, ok)
+
+	err := client.doAPI(context.Background(), http.MethodGet, "/api/v1/accounts/verify_credentials", nil, nil)
+	assert.NoError(t, err)
+
+	limit, ok := tracker.current(bucket)
+	assert.True(t, ok)
+	assert.Equal(t, int64(300), limit.Limit)
+	assert.Equal(t, int64(297), limit.Remaining)
+	assert.Equal(t, time.Date(2024, 5, 6, 12, 35, 0, 123000000, time.UTC), limit.Reset.UTC())
+
+	// Responses without the headers keep the last known limit.
+	err = client.doAPI(context.Background(), http.MethodGet, "/api/v1/instance", nil, nil)
+	assert.NoError(t, err)
+	limit, ok = tracker.current(bucket)
+	assert.True(t, ok)
+	assert.Equal(t, int64(297), limit.Remaining)
+}
+
+func TestRateLimitTracker_Delay(t *testing.T) {
+	now := time.Date(2024, 5, 6, 12, 30, 0, 0, time.UTC)
+	header := func(remaining string, reset time.Time) http.Header {
+		h := http.Header{}
+		h.Set("X-RateLimit-Limit", "300")
+		h.Set("X-RateLimit-Remaining", remaining)
+		h.Set("X-RateLimit-Reset", reset.Format(time.RFC3339Nano))
+		return h
+	}
+
+	bucket := rateLimitBucket{account: "a", family: rateLimitFamilyDefault}
+	tracker := &rateLimitTracker{}
+	assert.Zero(t, tracker.delay(bucket, now))
+
+	tracker.observe(bucket, header("200", now.Add(time.Minute)))
+	assert.Zero(t, tracker.delay(bucket, now))
+
+	tracker.observe(bucket, header("3", now.Add(time.Minute)))
+	assert.Equal(t, time.Minute, tracker.delay(bucket, now))
+
+	// A window that already reset does not hold requests back.
+	tracker.observe(bucket, header("0", now.Add(-time.Second)))
+	assert.Zero(t, tracker.delay(bucket, now))
+}
+
+func TestRateLimitBucketOf(t *testing.T) {
+	bucket := func(method, path, token string) rateLimitBucket {
+		req := httptest.NewRequest(method, "https://mastodon.example"+path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return rateLimitBucketOf(req)
+	}
+
+	assert.Equal(t, rateLimitBucket{account: "a", family: rateLimitFamilyDefault}, bucket(http.MethodGet, "/api/v1/accounts/verify_credentials", "a"))
+	assert.Equal(t, rateLimitBucket{family: rateLimitFamilyDefault}, bucket(http.MethodGet, "/api/v1/instance", ""))
+	assert.Equal(t, rateLimitBucket{account: "a", family: rateLimitFamilyMedia}, bucket(http.MethodPost, "/api/v2/media", "a"))
+	assert.Equal(t, rateLimitBucket{account: "a", family: rateLimitFamilyMedia}, bucket(http.MethodPost, "/api/v1/media", "a"))
+	assert.Equal(t, rateLimitBucket{account: "a", family: rateLimitFamilyDefault}, bucket(http.MethodPut, "/api/v1/media/1", "a"))
+	assert.Equal(t, rateLimitBucket{account: "a", family: rateLimitFamilyStatusDeletes}, bucket(http.MethodDelete, "/api/v1/statuses/1", "a"))
+	assert.Equal(t, rateLimitBucket{account: "a", family: rateLimitFamilyStatusDeletes}, bucket(http.MethodPost, "/api/v1/statuses/1/unreblog", "a"))
+	assert.Equal(t, rateLimitBucket{account: "a", family: rateLimitFamilyDefault}, bucket(http.MethodPost, "/api/v1/statuses/1/reblog", "a"))
+	assert.Equal(t, rateLimitBucket{account: "b", family: rateLimitFamilyDefault}, bucket(http.MethodPost, "/api/v1/statuses", "b"))
+}
+
+func TestRateLimitTransport_ThrottleMixedBuckets(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the media upload limit of one account is used up.
+		remaining := "290"
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v2/media" && r.Header.Get("Authorization") == "Bearer a" {
+			remaining = "0"
+		}
+		w.Header().Set("X-RateLimit-Limit", "300")
+		w.Header().Set("X-RateLimit-Remaining", remaining)
+		w.Header().Set("X-RateLimit-Reset", reset.Format(time.RFC3339Nano))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracker := &rateLimitTracker{}
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, tracker, true)}
+	send := func(method, path, token string) time.Duration {
+		req, err := http.NewRequestWithContext(context.Background(), method, server.URL+path, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		start := time.Now()
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return time.Since(start)
+	}
+
+	send(http.MethodPost, "/api/v2/media", "a")
+	assert.Greater(t, tracker.delay(rateLimitBucket{account: "a", family: rateLimitFamilyMedia}, time.Now()), time.Duration(0))
+
+	// Other requests of the account, and media uploads of other accounts,
+	// are not held back by the used up media limit.
+	assert.Less(t, send(http.MethodGet, "/api/v1/accounts/verify_credentials", "a"), time.Second)
+	assert.Less(t, send(http.MethodDelete, "/api/v1/statuses/1", "a"), time.Second)
+	assert.Less(t, send(http.MethodPost, "/api/v2/media", "b"), time.Second)
+	assert.Zero(t, tracker.delay(rateLimitBucket{account: "a", family: rateLimitFamilyDefault}, time.Now()))
+	assert.Zero(t, tracker.delay(rateLimitBucket{account: "b", family: rateLimitFamilyMedia}, time.Now()))
+}
+
+func TestRateLimitTransport_Throttle(t *testing.T) {
+	var reset time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "300")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", reset.Format(time.RFC3339Nano))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	get := func(client *http.Client) time.Duration {
+		start := time.Now()
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return time.Since(start)
+	}
+
+	reset = time.Now().Add(200 * time.Millisecond)
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, &rateLimitTracker{}, true)}
+	get(client)
+	assert.GreaterOrEqual(t, get(client), 100*time.Millisecond)
+
+	// The provider's request timeout starts after the wait.
+	reset = time.Now().Add(300 * time.Millisecond)
+	client = &http.Client{Transport: newRateLimitTransport(newTimeoutTransport(http.DefaultTransport, 100*time.Millisecond), &rateLimitTracker{}, true)}
+	get(client)
+	assert.GreaterOrEqual(t, get(client), 200*time.Millisecond)
+
+	// Waits that would outlast a deadline of the request itself are skipped.
+	reset = time.Now().Add(time.Hour)
+	client = &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, &rateLimitTracker{}, true), Timeout: time.Second}
+	get(client)
+	assert.Less(t, get(client), time.Second)
+
+	// Without throttling, requests are sent right away.
+	client = &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, &rateLimitTracker{}, false)}
+	get(client)
+	assert.Less(t, get(client), time.Second)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// handleRedactor replaces account handles in log output with hashes when
+// `redact_handles_in_logs` is set. The hash is salted with a value generated
+// once per provider run, so a handle always maps to the same hash within a
+// run but cannot be looked up across runs.
+type handleRedactor struct {
+	salt []byte
+}
+
+func newHandleRedactor() (*handleRedactor, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return &handleRedactor{salt: salt}, nil
+}
+
+// redact returns the hash standing in for the handle. Handles are compared
+// case-insensitively and without a leading `@`, like the server does.
+func (r *handleRedactor) redact(handle string) string {
+	h := sha256.New()
+	h.Write(r.salt)
+	h.Write([]byte(strings.ToLower(strings.TrimPrefix(handle, "@"))))
+	return "handle-" + hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// logHandle returns the form of an account handle that may be written to the
+// logs.
+func (c *MastodonClient) logHandle(handle string) string {
+	if c.redactor == nil {
+		return handle
+	}
+	return c.redactor.redact(handle)
+}
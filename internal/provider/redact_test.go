@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogHandle_Redacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mastodon.Results{})
+	}))
+	defer server.Close()
+
+	redactor, err := newHandleRedactor()
+	assert.NoError(t, err)
+	client := &MastodonClient{
+		Client:   mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		redactor: redactor,
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client.unresolvedMentions(ctx, "Hi @tedivm@hachyderm.io and @Gargron@mastodon.social")
+	client.unresolvedMentions(ctx, "Hi again @TEDIVM@hachyderm.io")
+
+	assert.NotContains(t, output.String(), "tedivm")
+	assert.NotContains(t, output.String(), "Gargron")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, redactor.redact("tedivm@hachyderm.io"), entries[0]["handle"])
+		assert.Equal(t, redactor.redact("Gargron@mastodon.social"), entries[1]["handle"])
+		// The same handle always maps to the same hash.
+		assert.Equal(t, entries[0]["handle"], entries[2]["handle"])
+		assert.NotEqual(t, entries[0]["handle"], entries[1]["handle"])
+	}
+}
+
+func TestLogHandle_NotRedacted(t *testing.T) {
+	client := &MastodonClient{}
+	assert.Equal(t, "tedivm@hachyderm.io", client.logHandle("tedivm@hachyderm.io"))
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RelationshipDataSource{}
+
+func NewRelationshipDataSource() datasource.DataSource {
+	return &RelationshipDataSource{}
+}
+
+// RelationshipDataSource defines the data source implementation.
+type RelationshipDataSource struct {
+	client *MastodonClient
+}
+
+// RelationshipDataSourceModel describes the data source data model.
+type RelationshipDataSourceModel struct {
+	AccountId           types.String `tfsdk:"account_id"`
+	Following           types.Bool   `tfsdk:"following"`
+	FollowedBy          types.Bool   `tfsdk:"followed_by"`
+	Requested           types.Bool   `tfsdk:"requested"`
+	Blocking            types.Bool   `tfsdk:"blocking"`
+	Muting              types.Bool   `tfsdk:"muting"`
+	MutingNotifications types.Bool   `tfsdk:"muting_notifications"`
+	ShowingReblogs      types.Bool   `tfsdk:"showing_reblogs"`
+	Notifying           types.Bool   `tfsdk:"notifying"`
+	Endorsed            types.Bool   `tfsdk:"endorsed"`
+	Note                types.String `tfsdk:"note"`
+	Languages           types.List   `tfsdk:"languages"`
+}
+
+// relationship mirrors the relationship entity, including the fields the
+// mastodon library does not decode.
+type relationship struct {
+	mastodon.Relationship
+	Notifying bool     `json:"notifying"`
+	Languages []string `json:"languages"`
+	Note      string   `json:"note"`
+}
+
+func (d *RelationshipDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_relationship"
+}
+
+func (d *RelationshipDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads the relationship between the authenticated account and another account.",
+
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the account to read the relationship with.",
+				Required:            true,
+			},
+			"following": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account follows this account.",
+				Computed:            true,
+			},
+			"followed_by": schema.BoolAttribute{
+				MarkdownDescription: "Whether this account follows the authenticated account.",
+				Computed:            true,
+			},
+			"requested": schema.BoolAttribute{
+				MarkdownDescription: "Whether a follow request to this account is pending.",
+				Computed:            true,
+			},
+			"blocking": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account blocks this account.",
+				Computed:            true,
+			},
+			"muting": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account mutes this account.",
+				Computed:            true,
+			},
+			"muting_notifications": schema.BoolAttribute{
+				MarkdownDescription: "Whether notifications from this account are muted.",
+				Computed:            true,
+			},
+			"showing_reblogs": schema.BoolAttribute{
+				MarkdownDescription: "Whether boosts from this account are shown in the home timeline.",
+				Computed:            true,
+			},
+			"notifying": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account is notified when this account posts.",
+				Computed:            true,
+			},
+			"endorsed": schema.BoolAttribute{
+				MarkdownDescription: "Whether this account is featured on the authenticated account's profile.",
+				Computed:            true,
+			},
+			"note": schema.StringAttribute{
+				MarkdownDescription: "The private note the authenticated account has left on this account. Null when there is no note.",
+				Computed:            true,
+			},
+			"languages": schema.ListAttribute{
+				MarkdownDescription: "The languages of posts from this account shown in the home timeline. Null when every language is shown.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RelationshipDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RelationshipDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RelationshipDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_relationship data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rel, err := d.client.getRelationship(ctx, data.AccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read relationship",
+			fmt.Sprintf("Failed to read relationship: %s", err),
+		)
+		return
+	}
+
+	if rel == nil {
+		resp.Diagnostics.AddError(
+			"Failed to read relationship",
+			fmt.Sprintf("The server returned no relationship for account %s.", data.AccountId.ValueString()),
+		)
+		return
+	}
+
+	data.Following = types.BoolValue(rel.Following)
+	data.FollowedBy = types.BoolValue(rel.FollowedBy)
+	data.Requested = types.BoolValue(rel.Requested)
+	data.Blocking = types.BoolValue(rel.Blocking)
+	data.Muting = types.BoolValue(rel.Muting)
+	data.MutingNotifications = types.BoolValue(rel.MutingNotifications)
+	data.ShowingReblogs = types.BoolValue(rel.ShowingReblogs)
+	data.Notifying = types.BoolValue(rel.Notifying)
+	data.Endorsed = types.BoolValue(rel.Endorsed)
+	data.Note = stringValueOrNull(rel.Note)
+
+	if len(rel.Languages) == 0 {
+		data.Languages = types.ListNull(types.StringType)
+	} else {
+		languages, diags := types.ListValueFrom(ctx, types.StringType, rel.Languages)
+		resp.Diagnostics.Append(diags...)
+		data.Languages = languages
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_relationship data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccRelationshipDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccRelationshipDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.mastodon_relationship.test", "account_id", "data.mastodon_account.test", "id"),
+					resource.TestCheckResourceAttrSet("data.mastodon_relationship.test", "following"),
+					resource.TestCheckResourceAttrSet("data.mastodon_relationship.test", "notifying"),
+					resource.TestCheckResourceAttrSet("data.mastodon_relationship.test", "endorsed"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRelationshipDataSourceConfig = `
+data "mastodon_account" "test" {
+  username = "tedivm@hachyderm.io"
+}
+
+data "mastodon_relationship" "test" {
+  account_id = data.mastodon_account.test.id
+}
+`
+
+func TestRelationshipDataSource_Read(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("id[]") {
+		case "1":
+			_, _ = w.Write([]byte(`[{"id":"1","following":true,"note":"Met at a conference","languages":["en","de"]}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"id":"2","note":"","languages":[]}]`))
+		default:
+			_, _ = w.Write([]byte(`[{"id":"3","note":null,"languages":null}]`))
+		}
+	}))
+	defer server.Close()
+
+	d := &RelationshipDataSource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	read := func(accountID string) RelationshipDataSourceModel {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		diags := config.Set(context.Background(), &RelationshipDataSourceModel{
+			AccountId: types.StringValue(accountID),
+			Languages: types.ListNull(types.StringType),
+		})
+		assert.False(t, diags.HasError(), diags)
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var data RelationshipDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+		return data
+	}
+
+	data := read("1")
+	assert.Equal(t, types.BoolValue(true), data.Following)
+	assert.Equal(t, types.StringValue("Met at a conference"), data.Note)
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("en"), types.StringValue("de")}), data.Languages)
+
+	// An empty note and an empty list of languages are reported as null.
+	data = read("2")
+	assert.Equal(t, types.StringNull(), data.Note)
+	assert.Equal(t, types.ListNull(types.StringType), data.Languages)
+
+	data = read("3")
+	assert.Equal(t, types.StringNull(), data.Note)
+	assert.Equal(t, types.ListNull(types.StringType), data.Languages)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ResolvedIdentityDataSource{}
+
+func NewResolvedIdentityDataSource() datasource.DataSource {
+	return &ResolvedIdentityDataSource{}
+}
+
+// ResolvedIdentityDataSource defines the data source implementation.
+type ResolvedIdentityDataSource struct {
+	client *MastodonClient
+}
+
+// ResolvedIdentityDataSourceModel describes the data source data model.
+type ResolvedIdentityDataSourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Server   types.String `tfsdk:"server"`
+	Handle   types.String `tfsdk:"handle"`
+	Id       types.String `tfsdk:"id"`
+}
+
+func (d *ResolvedIdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resolved_identity"
+}
+
+func (d *ResolvedIdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source resolves a user on a server to their canonical `handle` and account `id`, failing if the account does not exist. " +
+			"The lookup goes through the configured server, which fetches remote accounts from their home server when it does not know them yet, " +
+			"so `id` is the account's ID on the configured server. " +
+			"It takes the place of a `resolve_identity` provider function: Terraform does not pass provider configuration to functions, " +
+			"so a function could not use the configured server, credentials, timeout or proxy.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username to resolve, with or without the leading `@`.",
+				Required:            true,
+			},
+			"server": schema.StringAttribute{
+				MarkdownDescription: "The server the user is hosted on, e.g. `hachyderm.io`. May also be given as a URL.",
+				Required:            true,
+			},
+			"handle": schema.StringAttribute{
+				MarkdownDescription: "The canonical handle of the account, e.g. `@tedivm@hachyderm.io`.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the account on the configured server.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ResolvedIdentityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ResolvedIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ResolvedIdentityDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_resolved_identity data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	handle, id, err := d.client.resolveIdentity(ctx, data.Username.ValueString(), data.Server.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to resolve identity",
+			fmt.Sprintf("Failed to resolve identity: %s", err),
+		)
+		return
+	}
+
+	data.Handle = types.StringValue(handle)
+	data.Id = types.StringValue(id)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_resolved_identity data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// resolveIdentity looks the user up through the configured server and returns
+// their canonical handle and account ID. The server may be given as a bare
+// domain or as a URL.
+func (c *MastodonClient) resolveIdentity(ctx context.Context, username string, server string) (string, string, error) {
+	username = strings.TrimPrefix(username, "@")
+	domain := server
+	if _, rest, found := strings.Cut(domain, "://"); found {
+		domain = rest
+	}
+	domain = strings.TrimSuffix(domain, "/")
+
+	handle := c.normalizeHandle(username + "@" + domain)
+
+	var account mastodon.Account
+	err := c.doAPI(ctx, http.MethodGet, "/api/v1/accounts/lookup", url.Values{"acct": {handle}}, &account)
+	if isNotFound(err) && strings.Contains(handle, "@") {
+		// The server does not know the remote account yet, so it is fetched
+		// from its home server.
+		results, searchErr := c.Search(ctx, "@"+handle, true)
+		if searchErr != nil {
+			return "", "", fmt.Errorf("unable to resolve @%s@%s: %s", username, domain, searchErr)
+		}
+		for _, result := range results.Accounts {
+			if strings.EqualFold(result.Acct, handle) {
+				account, err = *result, nil
+				break
+			}
+		}
+	}
+	if isNotFound(err) {
+		return "", "", fmt.Errorf("the account @%s@%s does not exist", username, domain)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("unable to resolve @%s@%s: %s", username, domain, err)
+	}
+
+	// Local accounts are reported without a domain.
+	acct := account.Acct
+	if !strings.Contains(acct, "@") {
+		acct += "@" + c.localDomain()
+	}
+	return "@" + acct, string(account.ID), nil
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+// newIdentityServer serves the account lookup for a single local account, and
+// resolves a single remote account through search.
+func newIdentityServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/accounts/lookup" && r.URL.Query().Get("acct") == "tedivm":
+			_ = json.NewEncoder(w).Encode(mastodon.Account{ID: "109287393282745345", Username: "tedivm", Acct: "tedivm"})
+		case r.URL.Path == "/api/v2/search" && r.URL.Query().Get("resolve") == "true":
+			results := mastodon.Results{Accounts: []*mastodon.Account{}}
+			if r.URL.Query().Get("q") == "@alice@remote.example" {
+				results.Accounts = append(results.Accounts, &mastodon.Account{ID: "42", Username: "alice", Acct: "alice@remote.example"})
+			}
+			_ = json.NewEncoder(w).Encode(results)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+		}
+	}))
+}
+
+func TestResolveIdentity(t *testing.T) {
+	server := newIdentityServer()
+	defer server.Close()
+	domain := strings.TrimPrefix(server.URL, "http://")
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	// A local account is looked up without its domain.
+	handle, id, err := client.resolveIdentity(context.Background(), "@tedivm", server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "@tedivm@"+domain, handle)
+	assert.Equal(t, "109287393282745345", id)
+
+	// A remote account the server does not know yet is resolved by search.
+	handle, id, err = client.resolveIdentity(context.Background(), "alice", "remote.example")
+	assert.NoError(t, err)
+	assert.Equal(t, "@alice@remote.example", handle)
+	assert.Equal(t, "42", id)
+
+	_, _, err = client.resolveIdentity(context.Background(), "nobody", server.URL)
+	assert.ErrorContains(t, err, "the account @nobody@"+domain+" does not exist")
+
+	_, _, err = client.resolveIdentity(context.Background(), "nobody", "remote.example")
+	assert.ErrorContains(t, err, "the account @nobody@remote.example does not exist")
+}
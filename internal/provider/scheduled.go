@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mattn/go-mastodon"
+)
+
+// publishedStatusClockSkew is how much earlier than scheduled a post may
+// appear to be published, allowing for clocks that are slightly off.
+const publishedStatusClockSkew = time.Minute
+
+// scheduledStatus is a post waiting to be published. The library's type
+// cannot decode the poll of a scheduled post.
+type scheduledStatus struct {
+	ID          mastodon.ID `json:"id"`
+	ScheduledAt time.Time   `json:"scheduled_at"`
+	Params      struct {
+		Visibility string `json:"visibility"`
+	} `json:"params"`
+	MediaAttachments []mastodon.Attachment `json:"media_attachments"`
+}
+
+// scheduledAt returns the time the post is scheduled for, or nil when it is
+// published right away.
+func (data *PostResourceModel) scheduledAt() (*time.Time, error) {
+	if data.ScheduledAt.IsNull() || data.ScheduledAt.IsUnknown() {
+		return nil, nil
+	}
+	scheduledAt, err := time.Parse(time.RFC3339, data.ScheduledAt.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("scheduled_at must be an RFC 3339 timestamp such as 2024-05-01T12:00:00Z, got %q", data.ScheduledAt.ValueString())
+	}
+	return &scheduledAt, nil
+}
+
+// setScheduledStatus updates the model with a post that is scheduled, which
+// has none of the attributes of a published post yet.
+func (data *PostResourceModel) setScheduledStatus(status *scheduledStatus, account mastodon.ID) {
+	data.Id = types.StringValue(string(status.ID))
+	data.Scheduled = types.BoolValue(true)
+	data.CreatedAt = types.StringNull()
+	data.EditedAt = types.StringNull()
+	data.Account = stringValueOrNull(string(account))
+	data.Uri = types.StringNull()
+	data.Url = types.StringNull()
+	if data.Visibility.IsUnknown() {
+		data.Visibility = types.StringValue(status.Params.Visibility)
+	}
+	if data.Language.IsUnknown() {
+		data.Language = types.StringNull()
+	}
+	data.Bookmarked = types.BoolValue(false)
+	data.Favourited = types.BoolValue(false)
+	data.Reblogged = types.BoolValue(false)
+	data.ContentHtml = types.StringNull()
+	data.Card = types.ObjectNull(cardAttrTypes)
+	data.Mentions = mentionsValue(nil)
+	data.Tags = tagsValue(nil)
+	data.MediaIds = mediaIDsValue(status.MediaAttachments, data.MediaIds)
+	if !data.Poll.IsNull() && !data.Poll.IsUnknown() {
+		poll := data.Poll.Attributes()
+		poll["expires_at"] = types.StringNull()
+		poll["own_votes"] = types.ListNull(types.Int64Type)
+		data.Poll = types.ObjectValueMust(pollAttrTypes, poll)
+	}
+
+	// Keep the configured timestamp unless the server moved the post.
+	if scheduledAt, err := data.scheduledAt(); err != nil || scheduledAt == nil || !scheduledAt.Equal(status.ScheduledAt) {
+		data.ScheduledAt = types.StringValue(status.ScheduledAt.UTC().Format(time.RFC3339))
+	}
+}
+
+// isPublishedAs reports whether the status is the one the scheduled post was
+// published as. Formatted content cannot be compared, so only the content
+// warning and parent are checked for it.
+func (data *PostResourceModel) isPublishedAs(status *mastodon.Status, scheduledAt time.Time) bool {
+	if status.CreatedAt.Before(scheduledAt.Add(-publishedStatusClockSkew)) {
+		return false
+	}
+	if status.SpoilerText != data.SpoilerText.ValueString() || idValueOrNull(status.InReplyToID).ValueString() != data.InReplyToId.ValueString() {
+		return false
+	}
+	if data.hasFormattedContent() {
+		return true
+	}
+
+	content := normalizeStatusText(statusText(status.Content))
+	configured := normalizeStatusText(data.Content.ValueString())
+	return content == configured || withoutLeadingMentions(content) == withoutLeadingMentions(configured)
+}
+
+// scheduleStatus schedules a post, which the server publishes at the
+// toot's ScheduledAt.
+func (c *MastodonClient) scheduleStatus(ctx context.Context, toot *mastodon.Toot, contentType string, idempotencyKey string) (*scheduledStatus, error) {
+	var status scheduledStatus
+	_, err := c.doAPIWithHeader(ctx, http.MethodPost, "/api/v1/statuses", statusParams(toot, contentType), idempotencyHeader(idempotencyKey), &status)
+	if isNotFound(err) && idempotencyKey != "" {
+		// The key was used for a post that has since been deleted.
+		return c.scheduleStatus(ctx, toot, contentType, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// getScheduledStatus reads a post that is not published yet. The mastodon
+// library has no scheduled post endpoints, so they are called directly.
+func (c *MastodonClient) getScheduledStatus(ctx context.Context, id string) (*scheduledStatus, error) {
+	var status scheduledStatus
+	if err := c.doAPI(ctx, http.MethodGet, "/api/v1/scheduled_statuses/"+url.PathEscape(id), nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// rescheduleStatus moves a post that is not published yet to another time.
+func (c *MastodonClient) rescheduleStatus(ctx context.Context, id string, scheduledAt time.Time) (*scheduledStatus, error) {
+	params := url.Values{}
+	params.Set("scheduled_at", scheduledAt.Format(time.RFC3339))
+
+	var status scheduledStatus
+	if err := c.doAPI(ctx, http.MethodPut, "/api/v1/scheduled_statuses/"+url.PathEscape(id), params, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// deleteScheduledStatus cancels a post that is not published yet.
+func (c *MastodonClient) deleteScheduledStatus(ctx context.Context, id string) error {
+	return c.doAPI(ctx, http.MethodDelete, "/api/v1/scheduled_statuses/"+url.PathEscape(id), nil, nil)
+}
+
+// findPublishedStatus searches the account's posts since the time a
+// scheduled post was due for the one it was published as, returning nil when
+// there is none. The pages are followed back to that time, however many posts
+// the account has made since.
+func (c *MastodonClient) findPublishedStatus(ctx context.Context, account mastodon.ID, scheduledAt time.Time, match func(*mastodon.Status) bool) (*mastodon.Status, error) {
+	since := scheduledAt.Add(-publishedStatusClockSkew)
+
+	// Posts are returned newest first, and the oldest match was published
+	// first.
+	var found *mastodon.Status
+	pg := mastodon.Pagination{Limit: timelinePageSize}
+	for {
+		requested := pg.MaxID
+		page, err := c.GetAccountStatuses(ctx, account, &pg)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range page {
+			if status.CreatedAt.Before(since) {
+				return found, nil
+			}
+			if match(status) {
+				found = status
+			}
+		}
+
+		// The library leaves the pagination untouched when the response has
+		// no Link header, so an unchanged max_id means there are no more
+		// pages.
+		if len(page) == 0 || pg.MaxID == "" || pg.MaxID == requested {
+			return found, nil
+		}
+		pg = mastodon.Pagination{MaxID: pg.MaxID, Limit: timelinePageSize}
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetScheduledStatus(t *testing.T) {
+	data := PostResourceModel{
+		ScheduledAt: types.StringValue("2024-05-01T14:00:00+02:00"),
+		Visibility:  types.StringUnknown(),
+		Language:    types.StringUnknown(),
+		MediaIds:    types.ListUnknown(types.StringType),
+		Poll:        types.ObjectNull(pollAttrTypes),
+	}
+	status := &scheduledStatus{ID: "5", ScheduledAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	status.Params.Visibility = "unlisted"
+
+	data.setScheduledStatus(status, "1")
+	assert.Equal(t, "5", data.Id.ValueString())
+	assert.True(t, data.Scheduled.ValueBool())
+	assert.True(t, data.CreatedAt.IsNull())
+	assert.Equal(t, "unlisted", data.Visibility.ValueString())
+	assert.True(t, data.MediaIds.IsNull())
+	// The configured timestamp is kept when it is the same time.
+	assert.Equal(t, "2024-05-01T14:00:00+02:00", data.ScheduledAt.ValueString())
+
+	status.ScheduledAt = time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	data.setScheduledStatus(status, "1")
+	assert.Equal(t, "2024-05-01T13:00:00Z", data.ScheduledAt.ValueString())
+}
+
+func TestScheduledPostChanges(t *testing.T) {
+	state := PostResourceModel{
+		Content:     types.StringValue("Good morning!"),
+		Visibility:  types.StringValue("public"),
+		ScheduledAt: types.StringValue("2024-05-01T12:00:00Z"),
+		Attachments: types.ListNull(types.ObjectType{AttrTypes: map[string]attr.Type{}}),
+		MediaIds:    types.ListNull(types.StringType),
+		Poll:        types.ObjectNull(pollAttrTypes),
+	}
+
+	// Moving the post is an edit.
+	plan := state
+	plan.ScheduledAt = types.StringValue("2024-05-01T13:00:00Z")
+	assert.Empty(t, scheduledPostChanges(plan, state))
+
+	plan.Content = types.StringValue("Good afternoon!")
+	plan.Visibility = types.StringValue("unlisted")
+	assert.Equal(t, path.Paths{path.Root("content"), path.Root("visibility")}, scheduledPostChanges(plan, state))
+
+	plan = state
+	plan.ScheduledAt = types.StringNull()
+	assert.Equal(t, path.Paths{path.Root("scheduled_at")}, scheduledPostChanges(plan, state))
+}
+
+func TestPostResource_ReadPublishedScheduledPost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/accounts/1/statuses":
+			// Newest first: the published post, then an older post with the
+			// same content.
+			_, _ = w.Write([]byte(`[
+				{"id":"12","created_at":"2024-05-01T12:00:10Z","content":"<p>Good morning!</p>","visibility":"public","account":{"id":"1"}},
+				{"id":"11","created_at":"2024-04-30T12:00:00Z","content":"<p>Good morning!</p>","visibility":"public","account":{"id":"1"}}
+			]`))
+		case "/api/v1/statuses/12":
+			_, _ = w.Write([]byte(`{"id":"12","created_at":"2024-05-01T12:00:10Z","content":"<p>Good morning!</p>","visibility":"public","account":{"id":"1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	r := &PostResource{client: &MastodonClient{
+		Client:      mastodon.NewClient(&mastodon.Config{Server: server.URL}),
+		currentUser: &mastodon.Account{ID: "1"},
+	}}
+	state := tfsdk.State(testPostConfig(t, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "5"),
+		"content":      tftypes.NewValue(tftypes.String, "Good morning!"),
+		"scheduled_at": tftypes.NewValue(tftypes.String, "2024-05-01T12:00:00Z"),
+		"scheduled":    tftypes.NewValue(tftypes.Bool, true),
+	}))
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data PostResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	assert.Equal(t, "12", data.Id.ValueString())
+	assert.False(t, data.Scheduled.ValueBool())
+	assert.Equal(t, "2024-05-01T12:00:00Z", data.ScheduledAt.ValueString())
+}
+
+func TestFindPublishedStatus(t *testing.T) {
+	var pages []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		maxID := r.URL.Query().Get("max_id")
+		pages = append(pages, maxID)
+		w.Header().Set("Content-Type", "application/json")
+		switch maxID {
+		case "":
+			// A full page of newer posts, none of them the published one.
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/accounts/1/statuses?max_id=20>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[
+				{"id":"22","created_at":"2024-05-03T12:00:00Z","content":"<p>Later</p>"},
+				{"id":"21","created_at":"2024-05-02T12:00:00Z","content":"<p>Later</p>"}
+			]`))
+		case "20":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/accounts/1/statuses?max_id=10>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[
+				{"id":"13","created_at":"2024-05-01T12:00:30Z","content":"<p>Good morning!</p>"},
+				{"id":"12","created_at":"2024-05-01T12:00:10Z","content":"<p>Good morning!</p>"},
+				{"id":"11","created_at":"2024-04-30T12:00:00Z","content":"<p>Good morning!</p>"}
+			]`))
+		default:
+			t.Errorf("unexpected request for posts before %s", maxID)
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	scheduledAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	status, err := client.findPublishedStatus(context.Background(), "1", scheduledAt, func(status *mastodon.Status) bool {
+		return statusText(status.Content) == "Good morning!"
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, status) {
+		// The oldest match since the scheduled time, found past the first
+		// page, without reading further back than that time.
+		assert.Equal(t, mastodon.ID("12"), status.ID)
+	}
+	assert.Equal(t, []string{"", "20"}, pages)
+}
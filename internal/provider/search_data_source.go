@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// searchTypes are the kinds of results a search can be limited to.
+var searchTypes = []string{"accounts", "hashtags", "statuses"}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SearchDataSource{}
+
+func NewSearchDataSource() datasource.DataSource {
+	return &SearchDataSource{}
+}
+
+// SearchDataSource defines the data source implementation.
+type SearchDataSource struct {
+	client *MastodonClient
+}
+
+// SearchDataSourceModel describes the data source data model.
+type SearchDataSourceModel struct {
+	Query      types.String `tfsdk:"query"`
+	Type       types.String `tfsdk:"type"`
+	Resolve    types.Bool   `tfsdk:"resolve"`
+	AccountIds types.List   `tfsdk:"account_ids"`
+	Hashtags   types.List   `tfsdk:"hashtags"`
+	StatusIds  types.List   `tfsdk:"status_ids"`
+}
+
+func (d *SearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+func (d *SearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source searches the instance for accounts, hashtags and statuses, e.g. to find an account by part of its display name. " +
+			"Servers without full text search only find statuses the authenticated account wrote, favourited, boosted, bookmarked or was mentioned in.",
+
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "The text to search for.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Limits the search to `accounts`, `hashtags` or `statuses`. Searches all of them when not set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(searchTypes...),
+				},
+			},
+			"resolve": schema.BoolAttribute{
+				MarkdownDescription: "Whether to look up remote accounts and statuses the server does not know yet when `query` is a handle or URL. Defaults to `false`.",
+				Optional:            true,
+			},
+			"account_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching accounts.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"hashtags": schema.ListAttribute{
+				MarkdownDescription: "Names of the matching hashtags, without the leading `#`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"status_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching statuses.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SearchDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_search data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, err := d.client.search(ctx, data.Query.ValueString(), data.Type.ValueString(), data.Resolve.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to search",
+			fmt.Sprintf("Failed to search for %q: %s", data.Query.ValueString(), err),
+		)
+		return
+	}
+
+	data.setResults(results)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_search data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setResults maps the search results onto the model.
+func (data *SearchDataSourceModel) setResults(results *mastodon.Results) {
+	accountIds := make([]attr.Value, 0, len(results.Accounts))
+	for _, account := range results.Accounts {
+		accountIds = append(accountIds, types.StringValue(string(account.ID)))
+	}
+	hashtags := make([]attr.Value, 0, len(results.Hashtags))
+	for _, tag := range results.Hashtags {
+		hashtags = append(hashtags, types.StringValue(tag.Name))
+	}
+	statusIds := make([]attr.Value, 0, len(results.Statuses))
+	for _, status := range results.Statuses {
+		statusIds = append(statusIds, types.StringValue(string(status.ID)))
+	}
+
+	data.AccountIds = types.ListValueMust(types.StringType, accountIds)
+	data.Hashtags = types.ListValueMust(types.StringType, hashtags)
+	data.StatusIds = types.ListValueMust(types.StringType, statusIds)
+}
+
+// search searches the instance. The mastodon library cannot limit the search
+// to one type of result, so the endpoint is called directly.
+func (c *MastodonClient) search(ctx context.Context, query string, searchType string, resolve bool) (*mastodon.Results, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("resolve", strconv.FormatBool(resolve))
+	if searchType != "" {
+		params.Set("type", searchType)
+	}
+
+	var results mastodon.Results
+	if err := c.doAPI(ctx, http.MethodGet, "/api/v2/search", params, &results); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
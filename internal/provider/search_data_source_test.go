@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSearchDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSearchDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mastodon_search.test", "account_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.mastodon_search.test", "hashtags.#", "0"),
+					resource.TestCheckResourceAttr("data.mastodon_search.test", "status_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSearchDataSourceConfig = `
+data "mastodon_search" "test" {
+  query   = "tedivm@hachyderm.io"
+  type    = "accounts"
+  resolve = true
+}
+`
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/search", r.URL.Path)
+		assert.Equal(t, "cats", r.URL.Query().Get("q"))
+		assert.Equal(t, "hashtags", r.URL.Query().Get("type"))
+		assert.Equal(t, "false", r.URL.Query().Get("resolve"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accounts":[],"statuses":[],"hashtags":[{"name":"cats"},{"name":"caturday"}]}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	results, err := client.search(context.Background(), "cats", "hashtags", false)
+	assert.NoError(t, err)
+
+	var data SearchDataSourceModel
+	data.setResults(results)
+	assert.Empty(t, data.AccountIds.Elements())
+	assert.Equal(t, `["cats","caturday"]`, data.Hashtags.String())
+	assert.Empty(t, data.StatusIds.Elements())
+}
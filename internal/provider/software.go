@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Server software families the provider can tell apart.
+const (
+	softwareMastodon = "mastodon"
+	softwarePleroma  = "pleroma"
+	softwareAkkoma   = "akkoma"
+)
+
+// compatibleVersionPattern matches the suffix forks append to the version
+// reported by the instance endpoint, e.g. "2.7.2 (compatible; Akkoma 3.10.0)".
+var compatibleVersionPattern = regexp.MustCompile(`\(compatible; ([^\s;)]+)`)
+
+// detectSoftware infers the server software from the version string reported
+// by the instance endpoint. Anything without a compatibility suffix is
+// treated as vanilla Mastodon.
+func detectSoftware(version string) string {
+	match := compatibleVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return softwareMastodon
+	}
+	return strings.ToLower(match[1])
+}
+
+// supportedContentTypes returns the content types the server accepts posts
+// in. Pleroma and Akkoma list them in their instance metadata and glitch-soc
+// in the instance configuration; vanilla Mastodon only takes plain text.
+func supportedContentTypes(inst *instance) []string {
+	switch software := detectSoftware(inst.Version); {
+	case (software == softwarePleroma || software == softwareAkkoma) && len(inst.Pleroma.Metadata.PostFormats) > 0:
+		return inst.Pleroma.Metadata.PostFormats
+	case len(inst.Configuration.Statuses.SupportedMimeTypes) > 0:
+		return inst.Configuration.Statuses.SupportedMimeTypes
+	default:
+		return []string{contentTypePlain}
+	}
+}
+
+// supportsEmojiReactions reports whether the server software exposes the
+// status emoji reaction endpoints.
+func supportsEmojiReactions(software string) bool {
+	return software == softwarePleroma || software == softwareAkkoma
+}
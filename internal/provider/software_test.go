@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSoftware(t *testing.T) {
+	assert.Equal(t, softwareMastodon, detectSoftware("4.2.10"))
+	assert.Equal(t, softwarePleroma, detectSoftware("2.7.2 (compatible; Pleroma 2.6.2)"))
+	assert.Equal(t, softwareAkkoma, detectSoftware("2.7.2 (compatible; Akkoma 3.10.0)"))
+}
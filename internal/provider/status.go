@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Content types a post can be written in. Only forks accept anything other
+// than plain text.
+const (
+	contentTypePlain    = "text/plain"
+	contentTypeMarkdown = "text/markdown"
+	contentTypeHTML     = "text/html"
+)
+
+// postContentTypes lists the content types a post can be written in.
+var postContentTypes = []string{contentTypePlain, contentTypeMarkdown, contentTypeHTML}
+
+// Breaks in the HTML the server renders plain text posts to.
+var (
+	paragraphBreakPattern = regexp.MustCompile(`(?i)</p>\s*<p[^>]*>`)
+	lineBreakPattern      = regexp.MustCompile(`(?i)<br\s*/?>`)
+)
+
+// statusText converts the HTML content of a status back to plain text,
+// keeping its paragraph and line breaks and decoding HTML entities. Links and
+// mentions are reduced to their text.
+func statusText(content string) string {
+	content = paragraphBreakPattern.ReplaceAllString(content, "\n\n")
+	content = lineBreakPattern.ReplaceAllString(content, "\n")
+	return html.UnescapeString(bluemonday.StrictPolicy().Sanitize(content))
+}
+
+// Whitespace the server does not keep as written.
+var (
+	horizontalSpacePattern = regexp.MustCompile(`[\t\p{Zs}]+`)
+	blankLinesPattern      = regexp.MustCompile(`\n{3,}`)
+)
+
+// normalizeStatusText reduces plain text content to the form that survives
+// being rendered to HTML by the server and converted back by statusText:
+// runs of spaces and blank lines collapse, lines are trimmed, and remote
+// mentions lose their domain.
+func normalizeStatusText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = lengthMentionPattern.ReplaceAllString(text, "$1")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(horizontalSpacePattern.ReplaceAllString(line, " "))
+	}
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// statusParams encodes a toot as the form parameters of the statuses
+// endpoint. The content type is omitted when empty.
+func statusParams(toot *mastodon.Toot, contentType string) url.Values {
+	params := url.Values{}
+	params.Set("status", toot.Status)
+	if toot.InReplyToID != "" {
+		params.Set("in_reply_to_id", string(toot.InReplyToID))
+	}
+	for _, media := range toot.MediaIDs {
+		params.Add("media_ids[]", string(media))
+	}
+	if toot.Poll != nil {
+		for _, option := range toot.Poll.Options {
+			params.Add("poll[options][]", option)
+		}
+		params.Set("poll[expires_in]", strconv.FormatInt(toot.Poll.ExpiresInSeconds, 10))
+		if toot.Poll.Multiple {
+			params.Set("poll[multiple]", "true")
+		}
+		if toot.Poll.HideTotals {
+			params.Set("poll[hide_totals]", "true")
+		}
+	}
+	if toot.Visibility != "" {
+		params.Set("visibility", toot.Visibility)
+	}
+	if toot.Language != "" {
+		params.Set("language", toot.Language)
+	}
+	if toot.Sensitive {
+		params.Set("sensitive", "true")
+	}
+	if toot.SpoilerText != "" {
+		params.Set("spoiler_text", toot.SpoilerText)
+	}
+	if toot.ScheduledAt != nil {
+		params.Set("scheduled_at", toot.ScheduledAt.Format(time.RFC3339))
+	}
+	if contentType != "" {
+		params.Set("content_type", contentType)
+	}
+	return params
+}
+
+// postStatus creates a post. The mastodon library cannot set the content
+// type, so the endpoint is called directly.
+func (c *MastodonClient) postStatus(ctx context.Context, toot *mastodon.Toot, contentType string, idempotencyKey string) (*mastodon.Status, error) {
+	var status mastodon.Status
+	_, err := c.doAPIWithHeader(ctx, http.MethodPost, "/api/v1/statuses", statusParams(toot, contentType), idempotencyHeader(idempotencyKey), &status)
+	if isNotFound(err) && idempotencyKey != "" {
+		// The key was used for a post that has since been deleted.
+		return c.postStatus(ctx, toot, contentType, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// idempotencyHeader returns the header that makes the server answer a repeated
+// request with the post it created the first time, instead of posting again.
+// Keys are remembered for an hour.
+func idempotencyHeader(key string) http.Header {
+	if key == "" {
+		return nil
+	}
+	return http.Header{"Idempotency-Key": {key}}
+}
+
+// updateStatus edits a post. The mastodon library cannot set the content
+// type, so the endpoint is called directly.
+func (c *MastodonClient) updateStatus(ctx context.Context, id mastodon.ID, toot *mastodon.Toot, contentType string) (*mastodon.Status, error) {
+	var status mastodon.Status
+	err := c.doAPI(ctx, http.MethodPut, "/api/v1/statuses/"+url.PathEscape(string(id)), statusParams(toot, contentType), &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// setPinned pins a post to the profile of its author, or unpins it. The
+// mastodon library has no pin endpoints, so they are called directly.
+func (c *MastodonClient) setPinned(ctx context.Context, id mastodon.ID, pinned bool) error {
+	action := "unpin"
+	if pinned {
+		action = "pin"
+	}
+	return c.doAPI(ctx, http.MethodPost, "/api/v1/statuses/"+url.PathEscape(string(id))+"/"+action, nil, nil)
+}
+
+// pinErrorDetail explains why the server refused to pin a post, which is
+// usually the limit on pinned posts.
+func pinErrorDetail(id string, err error) string {
+	var statusErr *apiStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnprocessableEntity {
+		return fmt.Sprintf("The server refused to pin post %s: %s. Mastodon only allows a few pinned posts per account, usually 5, "+
+			"and does not pin direct posts. Unpin another post, or set pinned to false.", id, statusErr.Message)
+	}
+	return fmt.Sprintf("Unable to pin or unpin post %s, got error: %s", id, err)
+}
+
+// checkContentType returns an error when the server does not accept posts
+// written in the content type. Servers that cannot be asked are given the
+// benefit of the doubt.
+func (c *MastodonClient) checkContentType(ctx context.Context, contentType string) error {
+	if contentType == contentTypePlain {
+		return nil
+	}
+
+	inst, err := c.cachedInstance(ctx)
+	if err != nil {
+		return nil
+	}
+
+	supported := supportedContentTypes(inst)
+	for _, candidate := range supported {
+		if candidate == contentType {
+			return nil
+		}
+	}
+	return fmt.Errorf("the server (%s %s) accepts posts in %v, but not %s", detectSoftware(inst.Version), inst.Version, supported, contentType)
+}
@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &StatusDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &StatusDataSource{}
+
+func NewStatusDataSource() datasource.DataSource {
+	return &StatusDataSource{}
+}
+
+// StatusDataSource defines the data source implementation.
+type StatusDataSource struct {
+	client *MastodonClient
+}
+
+// StatusDataSourceModel describes the data source data model.
+type StatusDataSourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	Uri        types.String `tfsdk:"uri"`
+	Url        types.String `tfsdk:"url"`
+	Content    types.String `tfsdk:"content"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+	Visibility types.String `tfsdk:"visibility"`
+	AccountId  types.String `tfsdk:"account_id"`
+	Mentions   types.List   `tfsdk:"mentions"`
+	Tags       types.List   `tfsdk:"tags"`
+	Card       types.Object `tfsdk:"card"`
+	Reactions  types.List   `tfsdk:"reactions"`
+
+	FavouritesCount types.Int64 `tfsdk:"favourites_count"`
+	ReblogsCount    types.Int64 `tfsdk:"reblogs_count"`
+	RepliesCount    types.Int64 `tfsdk:"replies_count"`
+}
+
+func (d *StatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_status"
+}
+
+func (d *StatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads a status, including statuses not managed by Terraform.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the status on the configured server. Exactly one of `id` or `url` must be set.",
+				Computed:            true,
+				Optional:            true,
+			},
+			"uri": schema.StringAttribute{
+				MarkdownDescription: "The ActivityPub identifier of the status, used by other servers to refer to it.",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The public URL of the status, e.g. `https://hachyderm.io/@tedivm/109372843234`. " +
+					"The status is resolved through the server's search, fetching it from its home server if needed.",
+				Computed: true,
+				Optional: true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content of the status as plain text, with HTML tags stripped and line breaks kept.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the status was created, as an RFC3339 timestamp.",
+				Computed:            true,
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "The status visibility: one of `public`, `unlisted`, `private`, or `direct`.",
+				Computed:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the account that posted the status.",
+				Computed:            true,
+			},
+			"favourites_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of times the status has been favourited, as known to the configured server.",
+				Computed:            true,
+			},
+			"reblogs_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of times the status has been boosted, as known to the configured server.",
+				Computed:            true,
+			},
+			"replies_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of replies to the status, as known to the configured server.",
+				Computed:            true,
+			},
+			"card": schema.SingleNestedAttribute{
+				MarkdownDescription: "The preview card the server generated for the first link in the status. Null when the status has no link, or the server has not fetched the preview yet.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the linked page.",
+						Computed:            true,
+					},
+					"title": schema.StringAttribute{
+						MarkdownDescription: "The title of the linked page.",
+						Computed:            true,
+					},
+					"description": schema.StringAttribute{
+						MarkdownDescription: "The description of the linked page.",
+						Computed:            true,
+					},
+					"image": schema.StringAttribute{
+						MarkdownDescription: "URL of the preview image. Null when the page has none.",
+						Computed:            true,
+					},
+					"provider_name": schema.StringAttribute{
+						MarkdownDescription: "The name of the site that published the page. Null when unknown.",
+						Computed:            true,
+					},
+				},
+			},
+			"mentions": schema.ListNestedAttribute{
+				MarkdownDescription: "The accounts mentioned in the status, in the order the server returns them. Empty when the status mentions no one.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the mentioned account.",
+							Computed:            true,
+						},
+						"acct": schema.StringAttribute{
+							MarkdownDescription: "The handle of the mentioned account: the username for local accounts, `username@domain` for remote ones.",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The profile URL of the mentioned account.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"reactions": schema.ListNestedAttribute{
+				MarkdownDescription: "The emoji reactions to the status, on servers that support them such as Pleroma and Akkoma. " +
+					"Empty when the status has none, and null on servers without emoji reactions such as vanilla Mastodon.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The emoji, either a Unicode emoji or the shortcode of a custom emoji.",
+							Computed:            true,
+						},
+						"count": schema.Int64Attribute{
+							MarkdownDescription: "The number of accounts that reacted with the emoji.",
+							Computed:            true,
+						},
+						"me": schema.BoolAttribute{
+							MarkdownDescription: "Whether the authenticated account reacted with the emoji.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"tags": schema.ListNestedAttribute{
+				MarkdownDescription: "The hashtags used in the status, in the order the server returns them. Empty when the status uses none.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the hashtag, without the leading `#`.",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The URL of the hashtag's timeline on the instance.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *StatusDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("url"),
+		),
+	}
+}
+
+func (d *StatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *StatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data StatusDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_status data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.Id.ValueString()
+	if data.Id.IsNull() {
+		results, err := d.client.Search(ctx, data.Url.ValueString(), true)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to resolve status URL",
+				fmt.Sprintf("Failed to resolve status URL: %s", err),
+			)
+			return
+		}
+		if len(results.Statuses) != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("url"),
+				"Unable to Resolve Status URL",
+				fmt.Sprintf("Expected the URL %q to resolve to exactly one status, got %d. Check that the URL links to a single public post.",
+					data.Url.ValueString(), len(results.Statuses)),
+			)
+			return
+		}
+		id = string(results.Statuses[0].ID)
+	}
+
+	status, err := d.client.GetStatus(ctx, mastodon.ID(id))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read status",
+			fmt.Sprintf("Failed to read status %s: %s", id, err),
+		)
+		return
+	}
+
+	data.setStatus(status)
+
+	reactions, err := d.client.reactions(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read status reactions",
+			fmt.Sprintf("Failed to read the reactions to status %s: %s", id, err),
+		)
+		return
+	}
+	data.Reactions = reactions
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_status data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setStatus maps a status entity onto the model.
+func (data *StatusDataSourceModel) setStatus(status *mastodon.Status) {
+	data.Id = types.StringValue(string(status.ID))
+	data.Uri = types.StringValue(status.URI)
+	data.Url = stringValueOrNull(status.URL)
+	data.Content = types.StringValue(statusText(status.Content))
+	data.CreatedAt = types.StringValue(status.CreatedAt.UTC().Format(time.RFC3339))
+	data.Visibility = types.StringValue(status.Visibility)
+	data.AccountId = types.StringValue(string(status.Account.ID))
+	data.Mentions = mentionsValue(status.Mentions)
+	data.Tags = tagsValue(status.Tags)
+	data.Card = cardValue(status.Card)
+	data.FavouritesCount = types.Int64Value(status.FavouritesCount)
+	data.ReblogsCount = types.Int64Value(status.ReblogsCount)
+	data.RepliesCount = types.Int64Value(status.RepliesCount)
+	data.Reactions = types.ListNull(types.ObjectType{AttrTypes: reactionAttrTypes})
+}
+
+// reactions returns the emoji reactions to a status, null when the server
+// software does not support them or cannot be detected.
+func (c *MastodonClient) reactions(ctx context.Context, statusId string) (types.List, error) {
+	inst, err := c.cachedInstance(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "unable to detect the server software, skipping emoji reactions", map[string]interface{}{"error": err.Error()})
+		return types.ListNull(types.ObjectType{AttrTypes: reactionAttrTypes}), nil
+	}
+	if !supportsEmojiReactions(detectSoftware(inst.Version)) {
+		return types.ListNull(types.ObjectType{AttrTypes: reactionAttrTypes}), nil
+	}
+
+	reactions, err := c.statusReactions(ctx, statusId)
+	if err != nil {
+		return types.ListNull(types.ObjectType{AttrTypes: reactionAttrTypes}), err
+	}
+	return reactionsValue(reactions), nil
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccStatusDataSource_URL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccStatusDataSourceURLConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_status.by_id", "url"),
+					resource.TestCheckResourceAttrPair("data.mastodon_status.by_url", "id", "mastodon_post.test", "id"),
+					resource.TestCheckResourceAttr("data.mastodon_status.by_url", "content", "Status Lookup Test"),
+					resource.TestCheckResourceAttr("data.mastodon_status.by_id", "favourites_count", "0"),
+					resource.TestCheckResourceAttr("data.mastodon_status.by_id", "replies_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccStatusDataSourceURLConfig = `
+resource "mastodon_post" "test" {
+  content = "Status Lookup Test"
+}
+
+data "mastodon_status" "by_id" {
+  id = mastodon_post.test.id
+}
+
+data "mastodon_status" "by_url" {
+  url = data.mastodon_status.by_id.url
+}
+`
+
+func TestStatusDataSourceModel_SetStatus(t *testing.T) {
+	var data StatusDataSourceModel
+	data.setStatus(&mastodon.Status{
+		ID:         "109372843234",
+		URI:        "https://hachyderm.io/users/tedivm/statuses/109372843234",
+		URL:        "https://hachyderm.io/@tedivm/109372843234",
+		Content:    `<p><span class="h-card"><a href="https://mastodon.social/@Mastodon" class="u-url mention">@<span>Mastodon</span></a></span> Hello <a href="https://hachyderm.io/tags/terraform" class="mention hashtag" rel="tag">#<span>terraform</span></a></p>`,
+		CreatedAt:  time.Date(2022, 11, 19, 17, 4, 5, 0, time.FixedZone("EST", -5*60*60)),
+		Visibility: "public",
+		Account:    mastodon.Account{ID: "109366207541155278"},
+		Mentions: []mastodon.Mention{
+			{ID: "13179", Acct: "Mastodon@mastodon.social", URL: "https://mastodon.social/@Mastodon"},
+		},
+		Tags: []mastodon.Tag{
+			{Name: "terraform", URL: "https://hachyderm.io/tags/terraform"},
+		},
+		FavouritesCount: 12,
+		ReblogsCount:    3,
+		RepliesCount:    1,
+	})
+
+	assert.Equal(t, "109372843234", data.Id.ValueString())
+	assert.Equal(t, "https://hachyderm.io/users/tedivm/statuses/109372843234", data.Uri.ValueString())
+	assert.Equal(t, "https://hachyderm.io/@tedivm/109372843234", data.Url.ValueString())
+	assert.Equal(t, "@Mastodon Hello #terraform", data.Content.ValueString())
+	assert.Equal(t, "2022-11-19T22:04:05Z", data.CreatedAt.ValueString())
+	assert.Equal(t, "public", data.Visibility.ValueString())
+	assert.Equal(t, "109366207541155278", data.AccountId.ValueString())
+	assert.Equal(t, `[{"acct":"Mastodon@mastodon.social","id":"13179","url":"https://mastodon.social/@Mastodon"}]`, data.Mentions.String())
+	assert.Equal(t, `[{"name":"terraform","url":"https://hachyderm.io/tags/terraform"}]`, data.Tags.String())
+	assert.Equal(t, int64(12), data.FavouritesCount.ValueInt64())
+	assert.Equal(t, int64(3), data.ReblogsCount.ValueInt64())
+	assert.Equal(t, int64(1), data.RepliesCount.ValueInt64())
+	assert.True(t, data.Card.IsNull())
+
+	// Statuses without mentions or hashtags map to empty lists.
+	data.setStatus(&mastodon.Status{ID: "109372843235", Content: "<p>Hello</p>"})
+	assert.False(t, data.Mentions.IsNull())
+	assert.Empty(t, data.Mentions.Elements())
+	assert.False(t, data.Tags.IsNull())
+	assert.Empty(t, data.Tags.Elements())
+}
+
+func TestStatusReactions(t *testing.T) {
+	newServer := func(version string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v1/instance":
+				_, _ = w.Write([]byte(`{"uri":"example.com","version":"` + version + `"}`))
+			case "/api/v1/pleroma/statuses/7/reactions":
+				_, _ = w.Write([]byte(`[{"name":"🎉","count":3,"me":true},{"name":"blobcat","count":1,"me":false}]`))
+			case "/api/v1/pleroma/statuses/8/reactions":
+				_, _ = w.Write([]byte(`[]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	}
+
+	akkoma := newServer("2.7.2 (compatible; Akkoma 3.10.0)")
+	defer akkoma.Close()
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: akkoma.URL})}
+
+	reactions, err := client.reactions(context.Background(), "7")
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"count":3,"me":true,"name":"🎉"},{"count":1,"me":false,"name":"blobcat"}]`, reactions.String())
+
+	reactions, err = client.reactions(context.Background(), "8")
+	assert.NoError(t, err)
+	assert.False(t, reactions.IsNull())
+	assert.Empty(t, reactions.Elements())
+
+	// Vanilla Mastodon has no emoji reactions.
+	vanilla := newServer("4.2.0")
+	defer vanilla.Close()
+	client = &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: vanilla.URL})}
+
+	reactions, err = client.reactions(context.Background(), "7")
+	assert.NoError(t, err)
+	assert.True(t, reactions.IsNull())
+}
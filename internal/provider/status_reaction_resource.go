@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StatusReactionResource{}
+var _ resource.ResourceWithImportState = &StatusReactionResource{}
+
+func NewStatusReactionResource() resource.Resource {
+	return &StatusReactionResource{}
+}
+
+// StatusReactionResource defines the resource implementation.
+type StatusReactionResource struct {
+	client *MastodonClient
+}
+
+// StatusReactionResourceModel describes the resource data model.
+type StatusReactionResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	StatusId    types.String `tfsdk:"status_id"`
+	Emoji       types.String `tfsdk:"emoji"`
+	AccessToken types.String `tfsdk:"access_token"`
+}
+
+// emojiReaction is a single entry returned by the Pleroma reactions endpoint.
+type emojiReaction struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+	Me    bool   `json:"me"`
+}
+
+// reactionAttrTypes describes an entry of the `reactions` of statuses.
+var reactionAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"count": types.Int64Type,
+	"me":    types.BoolType,
+}
+
+func (r *StatusReactionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_status_reaction"
+}
+
+func (r *StatusReactionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This resource is used to react to a status with an emoji. " +
+			"Emoji reactions are only available on servers that support them, such as Pleroma and Akkoma; vanilla Mastodon does not.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the reaction, in the form `status_id:emoji`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the status to react to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"emoji": schema.StringAttribute{
+				MarkdownDescription: "The emoji to react with. Either a unicode emoji or the shortcode of a custom emoji.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token of another account on the same instance to react as, instead of the provider's account. " +
+					"The provider's server and application credentials are still used. Changing it creates a new reaction.",
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StatusReactionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *StatusReactionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StatusReactionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	instance, err := client.cachedInstance(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance, got error: %s", err))
+		return
+	}
+
+	software := detectSoftware(instance.Version)
+	if !supportsEmojiReactions(software) {
+		resp.Diagnostics.AddError(
+			"Emoji Reactions Not Supported",
+			fmt.Sprintf("The server reports version %q (%s), which does not support emoji reactions on statuses. "+
+				"Emoji reactions are only available on forks such as Pleroma and Akkoma.", instance.Version, software),
+		)
+		return
+	}
+
+	if client.validateOnly {
+		tflog.Debug(ctx, "validate_only is enabled: skipping reaction creation.")
+		data.Id = types.StringValue(validateOnlyID)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	err = client.doAPI(ctx, http.MethodPut, reactionEndpoint(data.StatusId.ValueString(), data.Emoji.ValueString()), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create reaction, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.StatusId.ValueString() + ":" + data.Emoji.ValueString())
+
+	tflog.Trace(ctx, "created a status reaction")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StatusReactionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StatusReactionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "reaction was planned in validate_only mode: skipping read.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	reactions, err := client.statusReactions(ctx, data.StatusId.ValueString())
+	if isNotFound(err) {
+		// The status was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read reactions, got error: %s", err))
+		return
+	}
+
+	for _, reaction := range reactions {
+		if reaction.Name == data.Emoji.ValueString() && reaction.Me {
+			data.Id = types.StringValue(data.StatusId.ValueString() + ":" + data.Emoji.ValueString())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	// The reaction was removed outside of Terraform.
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *StatusReactionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update.
+	var data StatusReactionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StatusReactionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StatusReactionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.validateOnly || data.Id.ValueString() == validateOnlyID {
+		tflog.Debug(ctx, "validate_only is enabled: skipping reaction deletion.")
+		return
+	}
+
+	client, err := r.client.forAccessToken(ctx, data.AccessToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_token"), "Invalid Access Token", err.Error())
+		return
+	}
+
+	err = client.doAPI(ctx, http.MethodDelete, reactionEndpoint(data.StatusId.ValueString(), data.Emoji.ValueString()), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete reaction, got error: %s", err))
+		return
+	}
+}
+
+func (r *StatusReactionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	statusId, emoji, found := strings.Cut(req.ID, ":")
+	if !found || statusId == "" || emoji == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier in the form status_id:emoji, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status_id"), statusId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("emoji"), emoji)...)
+}
+
+// statusReactions returns the emoji reactions to a status.
+func (c *MastodonClient) statusReactions(ctx context.Context, statusId string) ([]emojiReaction, error) {
+	var reactions []emojiReaction
+	if err := c.doAPI(ctx, http.MethodGet, "/api/v1/pleroma/statuses/"+url.PathEscape(statusId)+"/reactions", nil, &reactions); err != nil {
+		return nil, err
+	}
+	return reactions, nil
+}
+
+// reactionsValue maps emoji reactions to a list, empty when there are none.
+func reactionsValue(reactions []emojiReaction) types.List {
+	values := make([]attr.Value, 0, len(reactions))
+	for _, reaction := range reactions {
+		values = append(values, types.ObjectValueMust(reactionAttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(reaction.Name),
+			"count": types.Int64Value(reaction.Count),
+			"me":    types.BoolValue(reaction.Me),
+		}))
+	}
+	return types.ListValueMust(types.ObjectType{AttrTypes: reactionAttrTypes}, values)
+}
+
+// reactionEndpoint returns the path used to add or remove a reaction. The
+// emoji is escaped when the request URL is built.
+func reactionEndpoint(statusId string, emoji string) string {
+	return "/api/v1/pleroma/statuses/" + url.PathEscape(statusId) + "/reactions/" + url.PathEscape(emoji)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccStatusReactionResource_Unsupported(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The acceptance test server runs vanilla Mastodon, which has no
+			// emoji reactions.
+			{
+				Config:      testAccStatusReactionResourceConfig,
+				ExpectError: regexp.MustCompile(`Emoji Reactions Not Supported`),
+			},
+		},
+	})
+}
+
+const testAccStatusReactionResourceConfig = `
+resource "mastodon_post" "test" {
+  content = "Reaction Test Post"
+}
+
+resource "mastodon_status_reaction" "test" {
+  status_id = mastodon_post.test.id
+  emoji     = "🎉"
+}
+`
+
+func TestStatusReactionResource_ReadDeletedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/pleroma/statuses/7/reactions" {
+			_, _ = w.Write([]byte(`[{"name":"🎉","count":1,"me":true}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+	}))
+	defer server.Close()
+
+	r := &StatusReactionResource{client: &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	read := func(statusID string) *fwresource.ReadResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		diags := state.Set(context.Background(), &StatusReactionResourceModel{
+			Id:          types.StringValue(statusID + ":🎉"),
+			StatusId:    types.StringValue(statusID),
+			Emoji:       types.StringValue("🎉"),
+			AccessToken: types.StringNull(),
+		})
+		assert.False(t, diags.HasError(), diags)
+
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		return resp
+	}
+
+	assert.False(t, read("7").State.Raw.IsNull())
+
+	// A status deleted outside of Terraform takes its reactions with it.
+	assert.True(t, read("8").State.Raw.IsNull())
+}
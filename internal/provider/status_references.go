@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mattn/go-mastodon"
+)
+
+// mentionAttrTypes describes an entry of the `mentions` list of posts and
+// statuses.
+var mentionAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"acct": types.StringType,
+	"url":  types.StringType,
+}
+
+// tagAttrTypes describes an entry of the `tags` list of posts and statuses.
+var tagAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"url":  types.StringType,
+}
+
+// mentionsValue maps the accounts mentioned in a status to a list, in the
+// order the server returns them. A status without mentions maps to an empty
+// list.
+func mentionsValue(mentions []mastodon.Mention) types.List {
+	elements := make([]attr.Value, 0, len(mentions))
+	for _, mention := range mentions {
+		elements = append(elements, types.ObjectValueMust(mentionAttrTypes, map[string]attr.Value{
+			"id":   types.StringValue(string(mention.ID)),
+			"acct": types.StringValue(mention.Acct),
+			"url":  types.StringValue(mention.URL),
+		}))
+	}
+	return types.ListValueMust(types.ObjectType{AttrTypes: mentionAttrTypes}, elements)
+}
+
+// tagsValue maps the hashtags used in a status to a list, in the order the
+// server returns them. A status without hashtags maps to an empty list.
+func tagsValue(tags []mastodon.Tag) types.List {
+	elements := make([]attr.Value, 0, len(tags))
+	for _, tag := range tags {
+		elements = append(elements, types.ObjectValueMust(tagAttrTypes, map[string]attr.Value{
+			"name": types.StringValue(tag.Name),
+			"url":  types.StringValue(tag.URL),
+		}))
+	}
+	return types.ListValueMust(types.ObjectType{AttrTypes: tagAttrTypes}, elements)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusParams(t *testing.T) {
+	params := statusParams(&mastodon.Toot{
+		Status:      "**Hello**",
+		InReplyToID: "109372843234",
+		MediaIDs:    []mastodon.ID{"1", "2"},
+		Visibility:  "unlisted",
+		Sensitive:   true,
+	}, contentTypeMarkdown)
+
+	assert.Equal(t, "**Hello**", params.Get("status"))
+	assert.Equal(t, "109372843234", params.Get("in_reply_to_id"))
+	assert.Equal(t, []string{"1", "2"}, params["media_ids[]"])
+	assert.Equal(t, "unlisted", params.Get("visibility"))
+	assert.Equal(t, "true", params.Get("sensitive"))
+	assert.Equal(t, "text/markdown", params.Get("content_type"))
+	assert.False(t, params.Has("spoiler_text"))
+
+	params = statusParams(&mastodon.Toot{Status: "Hello"}, "")
+	assert.False(t, params.Has("content_type"))
+	assert.False(t, params.Has("poll[expires_in]"))
+
+	params = statusParams(&mastodon.Toot{
+		Status: "Tabs or spaces?",
+		Poll:   &mastodon.TootPoll{Options: []string{"Tabs", "Spaces"}, ExpiresInSeconds: 86400, HideTotals: true},
+	}, "")
+	assert.Equal(t, []string{"Tabs", "Spaces"}, params["poll[options][]"])
+	assert.Equal(t, "86400", params.Get("poll[expires_in]"))
+	assert.Equal(t, "true", params.Get("poll[hide_totals]"))
+	assert.False(t, params.Has("poll[multiple]"))
+}
+
+func TestCheckContentType(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		instance    string
+		contentType string
+		supported   bool
+	}{
+		{"akkoma markdown", `{"version":"2.7.2 (compatible; Akkoma 3.10.0)","pleroma":{"metadata":{"post_formats":["text/plain","text/html","text/markdown"]}}}`, contentTypeMarkdown, true},
+		{"glitch html", `{"version":"4.2.8+glitch","configuration":{"statuses":{"supported_mime_types":["text/plain","text/markdown","text/html"]}}}`, contentTypeHTML, true},
+		{"mastodon plain", `{"version":"4.2.8"}`, contentTypePlain, true},
+		{"mastodon markdown", `{"version":"4.2.8"}`, contentTypeMarkdown, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tc.instance))
+			}))
+			defer server.Close()
+
+			client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+			err := client.checkContentType(context.Background(), tc.contentType)
+			if tc.supported {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, "the server (mastodon 4.2.8) accepts posts in [text/plain], but not text/markdown")
+			}
+		})
+	}
+}
+
+func TestSetPinned(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/statuses/8/pin" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error":"Validation failed: You have already pinned the maximum number of posts"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"7"}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	assert.NoError(t, client.setPinned(context.Background(), "7", true))
+	assert.NoError(t, client.setPinned(context.Background(), "7", false))
+	assert.Equal(t, []string{"/api/v1/statuses/7/pin", "/api/v1/statuses/7/unpin"}, paths)
+
+	// The limit on pinned posts is explained.
+	err := client.setPinned(context.Background(), "8", true)
+	assert.Error(t, err)
+	assert.Contains(t, pinErrorDetail("8", err), "You have already pinned the maximum number of posts")
+	assert.Contains(t, pinErrorDetail("8", err), "usually 5")
+}
+
+func TestStatusText(t *testing.T) {
+	assert.Equal(t, "Hello & welcome\n\nsecond line\nthird line",
+		statusText(`<p>Hello &amp; welcome</p><p>second line<br />third line</p>`))
+	assert.Equal(t, "It's at https://example.com/a/long/path #terraform @tedivm",
+		statusText(`<p>It&#39;s at <a href="https://example.com/a/long/path" rel="nofollow noopener" target="_blank"><span class="invisible">https://</span><span class="ellipsis">example.com/a/long</span><span class="invisible">/path</span></a> `+
+			`<a href="https://mastodon.social/tags/terraform" class="mention hashtag" rel="tag">#<span>terraform</span></a> `+
+			`<span class="h-card"><a href="https://hachyderm.io/@tedivm" class="u-url mention">@<span>tedivm</span></a></span></p>`))
+	assert.Equal(t, "1 < 2", statusText(`<p>1 &lt; 2</p>`))
+}
+
+func TestPostStatus_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		// Proxies that do not know the header answer with a 404.
+		if r.Header.Get("Idempotency-Key") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Record not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"109372843234","content":"<p>Hello</p>"}`))
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+	status, err := client.postStatus(context.Background(), &mastodon.Toot{Status: "Hello"}, "", "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, mastodon.ID("109372843234"), status.ID)
+	assert.Equal(t, []string{"abc123", ""}, keys)
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+const (
+	// timelinePageSize is the largest page the timeline endpoints return.
+	timelinePageSize = 40
+
+	// defaultTimelineLimit matches the number of statuses the server returns
+	// by default.
+	defaultTimelineLimit = 20
+)
+
+// timelineTypes are the timelines the data source can read.
+var timelineTypes = []string{"home", "public", "local", "tag"}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TimelineDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &TimelineDataSource{}
+
+func NewTimelineDataSource() datasource.DataSource {
+	return &TimelineDataSource{}
+}
+
+// TimelineDataSource defines the data source implementation.
+type TimelineDataSource struct {
+	client *MastodonClient
+}
+
+// TimelineDataSourceModel describes the data source data model.
+type TimelineDataSourceModel struct {
+	Type     types.String          `tfsdk:"type"`
+	Tag      types.String          `tfsdk:"tag"`
+	Limit    types.Int64           `tfsdk:"limit"`
+	MaxId    types.String          `tfsdk:"max_id"`
+	Statuses []TimelineStatusModel `tfsdk:"statuses"`
+
+	SnapshotMaxId types.String `tfsdk:"snapshot_max_id"`
+}
+
+// TimelineStatusModel describes a status on a timeline.
+type TimelineStatusModel struct {
+	Id        types.String `tfsdk:"id"`
+	AccountId types.String `tfsdk:"account_id"`
+	Content   types.String `tfsdk:"content"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+func (d *TimelineDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_timeline"
+}
+
+func (d *TimelineDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads the most recent statuses of a timeline, e.g. to archive or analyze them. " +
+			"Live timelines change with every new status, so each read may return different statuses. " +
+			"To read the same statuses again, set `max_id` to the `snapshot_max_id` of an earlier read. " +
+			"Deleted statuses still drop out of a pinned read.",
+
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The timeline to read: `home` for the accounts the authenticated account follows, `public` for every known status, `local` for statuses of the instance, or `tag` for statuses with the hashtag in `tag`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(timelineTypes...),
+				},
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "The hashtag to read, with or without the leading `#`. Required when `type` is `tag`.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of statuses to return, up to 400. Defaults to %d.", defaultTimelineLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 400),
+				},
+			},
+			"max_id": schema.StringAttribute{
+				MarkdownDescription: "Only return statuses older than this ID, e.g. the `snapshot_max_id` of an earlier read.",
+				Optional:            true,
+			},
+			"snapshot_max_id": schema.StringAttribute{
+				MarkdownDescription: "The `max_id` that reads the same statuses again. This is `max_id` when it is set. " +
+					"Otherwise it is an ID just above the newest status returned, or that status's ID on servers without numeric IDs, such as Pleroma. " +
+					"Null when no statuses were returned.",
+				Computed: true,
+			},
+			"statuses": schema.ListNestedAttribute{
+				MarkdownDescription: "The statuses on the timeline, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the status.",
+							Computed:            true,
+						},
+						"account_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the account that posted the status.",
+							Computed:            true,
+						},
+						"content": schema.StringAttribute{
+							MarkdownDescription: "The text of the status, with the HTML removed.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "When the status was posted, as an RFC 3339 timestamp.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TimelineDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TimelineDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data TimelineDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() || data.Tag.IsUnknown() {
+		return
+	}
+
+	if data.Type.ValueString() == "tag" && data.Tag.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tag"),
+			"Missing Hashtag",
+			"The tag timeline needs the hashtag to read. Set tag to the hashtag.",
+		)
+	}
+	if data.Type.ValueString() != "tag" && !data.Tag.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tag"),
+			"Unexpected Hashtag",
+			fmt.Sprintf("The %s timeline is not limited to a hashtag. Set type to \"tag\" or remove tag.", data.Type.ValueString()),
+		)
+	}
+}
+
+func (d *TimelineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TimelineDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_timeline data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(defaultTimelineLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	statuses, err := d.client.timeline(ctx, data.Type.ValueString(), data.Tag.ValueString(), limit, data.MaxId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read timeline",
+			fmt.Sprintf("Failed to read the %s timeline: %s", data.Type.ValueString(), err),
+		)
+		return
+	}
+
+	data.setStatuses(statuses)
+
+	newest := ""
+	if len(statuses) > 0 {
+		newest = string(statuses[0].ID)
+	}
+	data.SnapshotMaxId = snapshotMaxIDValue(data.MaxId, newest)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_timeline data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setStatuses maps the statuses of the timeline onto the model.
+func (data *TimelineDataSourceModel) setStatuses(statuses []*mastodon.Status) {
+	data.Statuses = make([]TimelineStatusModel, 0, len(statuses))
+	for _, status := range statuses {
+		data.Statuses = append(data.Statuses, TimelineStatusModel{
+			Id:        types.StringValue(string(status.ID)),
+			AccountId: types.StringValue(string(status.Account.ID)),
+			Content:   types.StringValue(statusText(status.Content)),
+			CreatedAt: timeValueOrNull(status.CreatedAt),
+		})
+	}
+}
+
+// timeline returns up to limit of the most recent statuses of a timeline
+// older than maxID, following the pagination links until there are enough.
+// An empty maxID starts at the newest status.
+func (c *MastodonClient) timeline(ctx context.Context, timelineType string, tag string, limit int64, maxID string) ([]*mastodon.Status, error) {
+	fetch := func(pg *mastodon.Pagination) ([]*mastodon.Status, error) {
+		switch timelineType {
+		case "home":
+			return c.GetTimelineHome(ctx, pg)
+		case "public":
+			return c.GetTimelinePublic(ctx, false, pg)
+		case "local":
+			return c.GetTimelinePublic(ctx, true, pg)
+		case "tag":
+			return c.GetTimelineHashtag(ctx, strings.TrimPrefix(tag, "#"), false, pg)
+		}
+		return nil, fmt.Errorf("unsupported timeline %q", timelineType)
+	}
+
+	var statuses []*mastodon.Status
+	pg := mastodon.Pagination{MaxID: mastodon.ID(maxID), Limit: min(limit, timelinePageSize)}
+	for {
+		requested := pg.MaxID
+		page, err := fetch(&pg)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, page...)
+
+		// The library leaves the pagination untouched when the response has
+		// no Link header, so an unchanged max_id means there are no more
+		// pages.
+		if int64(len(statuses)) >= limit || len(page) == 0 || pg.MaxID == "" || pg.MaxID == requested {
+			return statuses[:min(int64(len(statuses)), limit)], nil
+		}
+		pg = mastodon.Pagination{MaxID: pg.MaxID, Limit: min(limit-int64(len(statuses)), timelinePageSize)}
+	}
+}
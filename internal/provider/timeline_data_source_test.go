@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccTimelineDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTimelineDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_timeline.test", "statuses.#"),
+				),
+			},
+			{
+				Config: `
+data "mastodon_timeline" "test" {
+  type = "tag"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing Hashtag"),
+			},
+		},
+	})
+}
+
+const testAccTimelineDataSourceConfig = `
+data "mastodon_timeline" "test" {
+  type  = "local"
+  limit = 5
+}
+`
+
+func TestTimeline(t *testing.T) {
+	var server *httptest.Server
+	var limits []string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/timelines/tag/caturday", r.URL.Path)
+		limits = append(limits, r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("max_id") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/timelines/tag/caturday?max_id=2>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[{"id":"3","account":{"id":"7"},"content":"<p>Cats &amp; dogs</p>","created_at":"2024-05-06T10:00:00.000Z"},{"id":"2","account":{"id":"8"},"content":"<p>Cats</p>"}]`))
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/timelines/tag/caturday?max_id=1>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[{"id":"1","account":{"id":"7"},"content":"<p>More cats</p>"}]`))
+		case "1":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request for %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := &MastodonClient{Client: mastodon.NewClient(&mastodon.Config{Server: server.URL})}
+
+	statuses, err := client.timeline(context.Background(), "tag", "#caturday", 3, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3", "1"}, limits)
+
+	var data TimelineDataSourceModel
+	data.setStatuses(statuses)
+	assert.Len(t, data.Statuses, 3)
+	assert.Equal(t, TimelineStatusModel{
+		Id:        types.StringValue("3"),
+		AccountId: types.StringValue("7"),
+		Content:   types.StringValue("Cats & dogs"),
+		CreatedAt: types.StringValue("2024-05-06T10:00:00Z"),
+	}, data.Statuses[0])
+	assert.True(t, data.Statuses[1].CreatedAt.IsNull())
+
+	// The limit stops the pagination early.
+	limits = nil
+	statuses, err = client.timeline(context.Background(), "tag", "caturday", 1, "")
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, []string{"1"}, limits)
+
+	// A max_id pins the read to older statuses.
+	limits = nil
+	statuses, err = client.timeline(context.Background(), "tag", "caturday", 20, "2")
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, mastodon.ID("1"), statuses[0].ID)
+	assert.Equal(t, []string{"20", "19"}, limits)
+}
+
+func TestSnapshotMaxIDValue(t *testing.T) {
+	assert.Equal(t, types.StringValue("110000000000000001"), snapshotMaxIDValue(types.StringNull(), "110000000000000000"))
+	assert.Equal(t, types.StringValue("200"), snapshotMaxIDValue(types.StringNull(), "199"))
+	assert.Equal(t, types.StringValue("1000"), snapshotMaxIDValue(types.StringNull(), "999"))
+	assert.Equal(t, types.StringNull(), snapshotMaxIDValue(types.StringNull(), ""))
+
+	// A pinned read stays pinned.
+	assert.Equal(t, types.StringValue("42"), snapshotMaxIDValue(types.StringValue("42"), "41"))
+
+	// Non-numeric IDs cannot be incremented.
+	assert.Equal(t, types.StringValue("AbCdEf"), snapshotMaxIDValue(types.StringNull(), "AbCdEf"))
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mattn/go-mastodon"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TrendsDataSource{}
+
+func NewTrendsDataSource() datasource.DataSource {
+	return &TrendsDataSource{}
+}
+
+// TrendsDataSource defines the data source implementation.
+type TrendsDataSource struct {
+	client *MastodonClient
+}
+
+// TrendsDataSourceModel describes the data source data model.
+type TrendsDataSourceModel struct {
+	Limit types.Int64      `tfsdk:"limit"`
+	Tags  []TrendsTagModel `tfsdk:"tags"`
+}
+
+// TrendsTagModel describes a trending tag.
+type TrendsTagModel struct {
+	Name      types.String            `tfsdk:"name"`
+	Url       types.String            `tfsdk:"url"`
+	History   []TrendsTagHistoryModel `tfsdk:"history"`
+	DailyUses map[string]types.Int64  `tfsdk:"daily_uses"`
+}
+
+// TrendsTagHistoryModel describes the usage of a tag on a single day.
+type TrendsTagHistoryModel struct {
+	Day      types.String `tfsdk:"day"`
+	Uses     types.Int64  `tfsdk:"uses"`
+	Accounts types.Int64  `tfsdk:"accounts"`
+}
+
+func (d *TrendsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trends"
+}
+
+func (d *TrendsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source reads the tags currently trending on the instance.",
+
+		Attributes: map[string]schema.Attribute{
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of tags to return, up to 20. Defaults to the server's default of 10.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 20),
+				},
+			},
+			"tags": schema.ListNestedAttribute{
+				MarkdownDescription: "The trending tags, most popular first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the tag, without the leading `#`.",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The URL of the tag's timeline on the instance.",
+							Computed:            true,
+						},
+						"history": schema.ListNestedAttribute{
+							MarkdownDescription: "Daily usage of the tag, most recent day first.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"day": schema.StringAttribute{
+										MarkdownDescription: "The day, as an RFC3339 date such as `2024-05-01`.",
+										Computed:            true,
+									},
+									"uses": schema.Int64Attribute{
+										MarkdownDescription: "The number of posts using the tag that day.",
+										Computed:            true,
+									},
+									"accounts": schema.Int64Attribute{
+										MarkdownDescription: "The number of accounts using the tag that day.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"daily_uses": schema.MapAttribute{
+							MarkdownDescription: "The same usage as `history`, flattened into a map of RFC3339 date to number of posts.",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TrendsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*MastodonClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MastodonClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TrendsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TrendsDataSourceModel
+
+	tflog.Debug(ctx, "mastodon_trends data source read")
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	if !data.Limit.IsNull() {
+		params.Set("limit", strconv.FormatInt(data.Limit.ValueInt64(), 10))
+	}
+
+	var tags []mastodon.Tag
+	err := d.client.doAPI(ctx, http.MethodGet, "/api/v1/trends/tags", params, &tags)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read trends",
+			fmt.Sprintf("Failed to read trends: %s", err),
+		)
+		return
+	}
+
+	data.Tags = make([]TrendsTagModel, 0, len(tags))
+	for _, tag := range tags {
+		model, err := newTrendsTagModel(tag)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read trends",
+				fmt.Sprintf("Failed to read the history of tag %q: %s", tag.Name, err),
+			)
+			return
+		}
+		data.Tags = append(data.Tags, model)
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read the mastodon_trends data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// newTrendsTagModel maps a tag entity onto the model. The API reports days
+// as UNIX timestamps of midnight UTC and counts as strings.
+func newTrendsTagModel(tag mastodon.Tag) (TrendsTagModel, error) {
+	model := TrendsTagModel{
+		Name:      types.StringValue(tag.Name),
+		Url:       types.StringValue(tag.URL),
+		History:   make([]TrendsTagHistoryModel, 0, len(tag.History)),
+		DailyUses: make(map[string]types.Int64, len(tag.History)),
+	}
+
+	for _, history := range tag.History {
+		timestamp, err := strconv.ParseInt(history.Day, 10, 64)
+		if err != nil {
+			return model, fmt.Errorf("invalid day %q: %w", history.Day, err)
+		}
+		uses, err := strconv.ParseInt(history.Uses, 10, 64)
+		if err != nil {
+			return model, fmt.Errorf("invalid uses %q: %w", history.Uses, err)
+		}
+		accounts, err := strconv.ParseInt(history.Accounts, 10, 64)
+		if err != nil {
+			return model, fmt.Errorf("invalid accounts %q: %w", history.Accounts, err)
+		}
+
+		day := time.Unix(timestamp, 0).UTC().Format(time.DateOnly)
+		model.History = append(model.History, TrendsTagHistoryModel{
+			Day:      types.StringValue(day),
+			Uses:     types.Int64Value(uses),
+			Accounts: types.Int64Value(accounts),
+		})
+		model.DailyUses[day] = types.Int64Value(uses)
+	}
+
+	return model, nil
+}
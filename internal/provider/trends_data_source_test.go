@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccTrendsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTrendsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mastodon_trends.test", "tags.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTrendsDataSourceConfig = `
+data "mastodon_trends" "test" {
+  limit = 5
+}
+`
+
+func TestNewTrendsTagModel(t *testing.T) {
+	model, err := newTrendsTagModel(mastodon.Tag{
+		Name: "caturday",
+		URL:  "https://mastodon.example/tags/caturday",
+		History: []mastodon.History{
+			{Day: "1714953600", Uses: "120", Accounts: "80"},
+			{Day: "1714867200", Uses: "34", Accounts: "30"},
+			{Day: "1714780800", Uses: "0", Accounts: "0"},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []TrendsTagHistoryModel{
+		{Day: types.StringValue("2024-05-06"), Uses: types.Int64Value(120), Accounts: types.Int64Value(80)},
+		{Day: types.StringValue("2024-05-05"), Uses: types.Int64Value(34), Accounts: types.Int64Value(30)},
+		{Day: types.StringValue("2024-05-04"), Uses: types.Int64Value(0), Accounts: types.Int64Value(0)},
+	}, model.History)
+	assert.Equal(t, map[string]types.Int64{
+		"2024-05-06": types.Int64Value(120),
+		"2024-05-05": types.Int64Value(34),
+		"2024-05-04": types.Int64Value(0),
+	}, model.DailyUses)
+
+	_, err = newTrendsTagModel(mastodon.Tag{History: []mastodon.History{{Day: "yesterday", Uses: "1", Accounts: "1"}}})
+	assert.Error(t, err)
+}